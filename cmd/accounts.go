@@ -0,0 +1,255 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+)
+
+// openWallet opens the wallet directory named by WALLET_DIR (default "wallet")
+func openWallet() (*wallet.Wallet, bool) {
+	w, err := wallet.OpenWallet(wallet.WalletDir())
+	if err != nil {
+		fmt.Printf("Error opening wallet: %v\n", err)
+		return nil, false
+	}
+	return w, true
+}
+
+// createAccount creates a new named account in the wallet
+func createAccount(args []string) {
+	if len(args) < 3 {
+		fmt.Println("Usage: cli account-create <name> [p256|ed25519]")
+		return
+	}
+	name := args[2]
+	schemeName := ""
+	if len(args) > 3 {
+		schemeName = args[3]
+	}
+	scheme, err := wallet.ParseScheme(schemeName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+
+	signer, err := wallet.GenerateSigner(scheme)
+	if err != nil {
+		fmt.Printf("Error generating key: %v\n", err)
+		return
+	}
+	addAccount(w, name, signer)
+}
+
+// importAccount adds an existing key to the wallet, from an encrypted or
+// plaintext key file or a hex private key
+func importAccount(args []string) {
+	if len(args) < 4 {
+		fmt.Println("Usage: cli account-import <name> <key-file|private-key-hex> [p256|ed25519]")
+		return
+	}
+	name, source := args[2], args[3]
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+
+	data, err := os.ReadFile(source)
+	switch {
+	case err == nil && !wallet.IsLegacyKeyFile(data):
+		// Encrypted key files are copied as they are, passphrase included
+		account, err := w.Import(name, source)
+		if err != nil {
+			fmt.Printf("Error importing %s: %v\n", source, err)
+			return
+		}
+		printAccountAdded(w, account)
+
+	case err == nil:
+		priv, err := wallet.LoadLegacyKey(source)
+		if err != nil {
+			fmt.Printf("Error importing %s: %v\n", source, err)
+			return
+		}
+		addAccount(w, name, wallet.NewP256Signer(priv))
+
+	default:
+		schemeName := ""
+		if len(args) > 4 {
+			schemeName = args[4]
+		}
+		scheme, err := wallet.ParseScheme(schemeName)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		signer, err := wallet.ParsePrivateKey(scheme, source)
+		if err != nil {
+			fmt.Printf("Error: %s is neither a key file nor a private key: %v\n", source, err)
+			return
+		}
+		addAccount(w, name, signer)
+	}
+}
+
+// addAccount encrypts a key with a new passphrase and stores it as an account
+func addAccount(w *wallet.Wallet, name string, signer wallet.Signer) {
+	passphrase, err := wallet.ReadPassphrase(fmt.Sprintf("New passphrase for %s: ", name), true)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	account, err := w.Create(name, signer, passphrase)
+	if err != nil {
+		fmt.Printf("Error creating account: %v\n", err)
+		return
+	}
+	printAccountAdded(w, account)
+}
+
+func printAccountAdded(w *wallet.Wallet, account *wallet.Account) {
+	fmt.Printf("✅ Account %s added (%s)\n", account.Name, wallet.SchemeName(account.Scheme))
+	fmt.Printf("Address: %s\n", account.Address)
+	fmt.Printf("💾 Key saved to: %s\n", w.KeyPath(account.Name))
+	if account.Default {
+		fmt.Println("⭐ This is the wallet's default account")
+	}
+}
+
+// listAccounts prints every account of the wallet
+func listAccounts() {
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+	accounts, err := w.Accounts()
+	if err != nil {
+		fmt.Printf("Error listing accounts: %v\n", err)
+		return
+	}
+	if len(accounts) == 0 {
+		fmt.Printf("Wallet %s has no accounts; create one with 'cli account-create <name>'\n", w.Dir())
+		return
+	}
+
+	fmt.Printf("Wallet %s:\n", w.Dir())
+	for _, account := range accounts {
+		marker := " "
+		if account.Default {
+			marker = "*"
+		}
+		kind := wallet.SchemeName(account.Scheme)
+		if account.WatchOnly {
+			kind = "watch"
+		}
+		fmt.Printf("%s %-16s %-8s %s\n", marker, account.Name, kind, account.Address)
+	}
+}
+
+// renameAccount renames an account of the wallet
+func renameAccount(args []string) {
+	if len(args) < 4 {
+		fmt.Println("Usage: cli account-rename <name> <new-name>")
+		return
+	}
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+	if err := w.Rename(args[2], args[3]); err != nil {
+		fmt.Printf("Error renaming account: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Account %s renamed to %s\n", args[2], args[3])
+}
+
+// setDefaultAccount selects the account used when --from is not given
+func setDefaultAccount(args []string) {
+	if len(args) < 3 {
+		fmt.Println("Usage: cli account-default <name>")
+		return
+	}
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+	if err := w.SetDefault(args[2]); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("⭐ %s is now the default account\n", args[2])
+}
+
+// exportAccount copies an account's encrypted key file out of the wallet, or
+// with --hex prints its private key after unlocking it
+func exportAccount(args []string) {
+	if len(args) < 4 {
+		fmt.Println("Usage: cli account-export <name> <file>")
+		fmt.Println("       cli account-export <name> --hex")
+		return
+	}
+	name, target := args[2], args[3]
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+
+	if target != "--hex" && target != "-hex" {
+		if err := w.Export(name, target); err != nil {
+			fmt.Printf("Error exporting account: %v\n", err)
+			return
+		}
+		fmt.Printf("💾 Encrypted key of %s written to %s\n", name, target)
+		return
+	}
+
+	signer, err := unlockAccount(w, name)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	secret, err := wallet.PrivateKeyHex(signer)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Println("⚠️  Anyone with this private key controls the account:")
+	fmt.Printf("%s (%s)\n", secret, wallet.SchemeName(signer.Scheme()))
+}
+
+// unlockAccount asks for the passphrase of the named (or default) account
+func unlockAccount(w *wallet.Wallet, name string) (wallet.Signer, error) {
+	account, err := w.Account(name)
+	if err != nil {
+		return nil, err
+	}
+	if account.WatchOnly {
+		return nil, fmt.Errorf("account %s is watch-only and cannot sign", account.Name)
+	}
+	passphrase, err := wallet.ReadPassphrase(fmt.Sprintf("Passphrase for %s: ", account.Name), false)
+	if err != nil {
+		return nil, err
+	}
+	return w.Unlock(account.Name, passphrase)
+}
+
+// resolveRecipient accepts an address or the name of an account of the wallet
+func resolveRecipient(w *wallet.Wallet, s string) ([]byte, error) {
+	if wallet.LooksLikeAddress(s) {
+		return wallet.ParseAddress(s)
+	}
+	account, err := w.Account(s)
+	if errors.Is(err, wallet.ErrNoAccount) {
+		return nil, fmt.Errorf("%q is neither an address nor an account of the wallet", s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return account.Address, nil
+}
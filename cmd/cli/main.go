@@ -2,11 +2,18 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/protoconv"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/signer"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
 	"github.com/nguyentrinhquy1411/blockchain-go/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -14,13 +21,52 @@ import (
 
 func main() {
 	var (
-		serverAddr = flag.String("server", "localhost:50051", "Server address")
-		command    = flag.String("cmd", "latest", "Command to execute: latest, send, get")
-		sender     = flag.String("sender", "Alice", "Transaction sender")
-		receiver   = flag.String("receiver", "Bob", "Transaction receiver")
-		amount     = flag.Float64("amount", 10.0, "Transaction amount")
+		serverAddr   = flag.String("server", "localhost:50051", "Server address")
+		command      = flag.String("cmd", "latest", "Command to execute: latest, send, issue, assets, balance, register-name, renew-name, transfer-name, lookup, anchor, get-anchor, approve, transfer-from, allowance, grant, vesting, account, rotate-key, build, sign, broadcast, scan, tx, history")
+		keyFile      = flag.String("key", "", "Encrypted key file of the transaction sender (instead of a wallet account)")
+		receiver     = flag.String("receiver", "Bob", "Transaction receiver (address or registered name)")
+		amount       = flag.Float64("amount", 10.0, "Transaction amount (total supply for issue)")
+		fee          = flag.Float64("fee", 0.01, "Transaction fee paid to the block proposer")
+		asset        = flag.String("asset", "", "Asset ID (empty for the native coin)")
+		symbol       = flag.String("symbol", "", "Asset symbol for issue")
+		decimals     = flag.Int("decimals", 0, "Asset decimals for issue")
+		address      = flag.String("address", "", "Address or name to query for balance, account and history")
+		name         = flag.String("name", "", "Name for register-name, renew-name, transfer-name and lookup")
+		file         = flag.String("file", "", "Document to anchor or look up")
+		docHash      = flag.String("hash", "", "SHA-256 document hash (hex) to anchor or look up; transaction hash for tx")
+		mimeType     = flag.String("content-type", "", "Optional content type of the anchored document")
+		embed        = flag.Bool("embed", false, "Store the document itself on-chain (max 4 KiB)")
+		owner        = flag.String("owner", "", "Owner whose allowance is spent or queried")
+		spender      = flag.String("spender", "", "Optional spender filter for allowance")
+		start        = flag.Int("start", 0, "Block height at which a vesting grant starts accruing")
+		cliff        = flag.Int("cliff", 0, "Block height before which nothing of a grant unlocks")
+		end          = flag.Int("end", 0, "Block height at which a vesting grant is fully unlocked")
+		from         = flag.String("from", "", "Sending wallet account (default: the wallet's default account); build also takes an address or registered name")
+		walletDir    = flag.String("wallet", wallet.WalletDir(), "Wallet directory holding the named accounts")
+		nonce        = flag.Uint64("nonce", 0, "Nonce for build (0 asks the node for the next one)")
+		outFile      = flag.String("out", "", "Output transaction file for build and sign (sign defaults to -file)")
+		signerSocket = flag.String("signer", "", "Signer daemon socket; -key then names a key of the daemon")
+		network      = flag.String("network", os.Getenv(wallet.NetworkEnv), "Address network: mainnet or testnet")
+		memo         = flag.String("memo", "", "Memo for send and build, encrypted so that only the receiver can read it")
+		newScheme    = flag.String("scheme", "", "Signature scheme of the new key for rotate-key: p256 or ed25519")
+		direction    = flag.String("direction", "", "History filter: sent, received or all")
+		limit        = flag.Int("limit", 0, "History page size (default 20, at most 100)")
+		cursor       = flag.String("cursor", "", "History page to show, as printed after the previous page")
+		oldestFirst  = flag.Bool("oldest", false, "List history from the oldest transaction")
 	)
 	flag.Parse()
+	// "tx show <hash>" after the flags is the same as -cmd=tx -hash=<hash>
+	if args := flag.Args(); len(args) == 3 && args[0] == "tx" && args[1] == "show" {
+		*command, *docHash = "tx", args[2]
+	} else if len(args) > 0 {
+		log.Fatalf("Unexpected arguments %q (usage: cli [flags] tx show <hash>)", args)
+	}
+
+	addressNetwork, err := wallet.ParseNetwork(*network)
+	if err != nil {
+		log.Fatalf("Invalid -network: %v", err)
+	}
+	wallet.SetNetwork(addressNetwork)
 
 	// Connect to server
 	conn, err := grpc.NewClient(*serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -30,6 +76,18 @@ func main() {
 	defer conn.Close()
 
 	client := proto.NewBlockchainServiceClient(conn)
+
+	// Unlock the signing key before starting the request deadline
+	var newKey wallet.Signer
+	var newPassphrase string
+	if *command == "rotate-key" {
+		newKey, newPassphrase = mustPrepareRotation(*keyFile, *signerSocket, *newScheme)
+	}
+	var txSigner signer.TransactionSigner
+	if txCommands[*command] {
+		txSigner = mustLoadSigner(*walletDir, *from, *keyFile, *signerSocket)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -45,25 +103,715 @@ func main() {
 		fmt.Printf("  Transactions: %d\n", len(resp.Block.Transactions))
 
 	case "send":
-		tx := &proto.Transaction{
-			Sender:    *sender,
-			Receiver:  *receiver,
+		tx := &blockchain.Transaction{
+			Receiver:  mustResolveAddress(ctx, client, *receiver),
 			Amount:    *amount,
 			Timestamp: time.Now().Unix(),
+			Asset:     *asset,
+		}
+		if *memo != "" {
+			tx.Memo = mustEncryptMemo(ctx, client, tx.Receiver, *memo)
+		}
+		resp := signAndSend(ctx, client, txSigner, tx, *fee)
+
+		fmt.Printf("Transaction sent: %s\n", resp.Message)
+		fmt.Printf("  %s -> %s (%s): %.2f\n", wallet.FormatAddress(tx.Sender), *receiver, wallet.FormatAddress(tx.Receiver), *amount)
+
+	case "issue":
+		issue, err := blockchain.NewIssueAssetTransaction(nil, *symbol, *decimals, *amount)
+		if err != nil {
+			log.Fatalf("Invalid asset: %v", err)
+		}
+
+		resp := signAndSend(ctx, client, txSigner, issue, *fee)
+
+		fmt.Printf("Issue transaction sent: %s\n", resp.Message)
+		fmt.Printf("  %s issues %.2f %s (%d decimals)\n", wallet.FormatAddress(issue.Sender), *amount, *symbol, *decimals)
+
+	case "assets":
+		resp, err := client.ListAssets(ctx, &proto.ListAssetsRequest{})
+		if err != nil {
+			log.Fatalf("Failed to list assets: %v", err)
+		}
+		fmt.Printf("Assets: %d\n", len(resp.Assets))
+		for _, a := range resp.Assets {
+			fmt.Printf("  %s  %-12s decimals=%d supply=%.2f issuer=%s height=%d\n",
+				a.Id, a.Symbol, a.Decimals, a.Supply, a.Issuer, a.Height)
 		}
 
-		resp, err := client.SendTransaction(ctx, &proto.SendTransactionRequest{
-			Transaction: tx,
+	case "balance":
+		resp, err := client.GetBalance(ctx, &proto.GetBalanceRequest{
+			Address: mustResolve(ctx, client, *address),
+			Asset:   *asset,
 		})
 		if err != nil {
-			log.Fatalf("Failed to send transaction: %v", err)
+			log.Fatalf("Failed to get balance: %v", err)
+		}
+		fmt.Printf("Balances of %s:\n", *address)
+		for _, b := range resp.Balances {
+			id := b.Asset
+			if id == "" {
+				id = "native"
+			}
+			fmt.Printf("  %-12s %-16s %.2f\n", b.Symbol, id, b.Balance)
 		}
 
-		fmt.Printf("Transaction sent: %s\n", resp.Message)
-		fmt.Printf("  %s -> %s: %.2f\n", *sender, *receiver, *amount)
+	case "register-name", "renew-name", "transfer-name":
+		txTypes := map[string]blockchain.TxType{
+			"register-name": blockchain.TxNameRegister,
+			"renew-name":    blockchain.TxNameRenew,
+			"transfer-name": blockchain.TxNameTransfer,
+		}
+		op, err := blockchain.NewNameTransaction(txTypes[*command], nil, *name, nil)
+		if err != nil {
+			log.Fatalf("Invalid name: %v", err)
+		}
+
+		if op.Type == blockchain.TxNameTransfer {
+			op.Receiver = mustResolveAddress(ctx, client, *receiver)
+		}
+
+		resp := signAndSend(ctx, client, txSigner, op, *fee)
+		fmt.Printf("Name transaction sent: %s\n", resp.Message)
+
+	case "lookup":
+		resp, err := client.ResolveName(ctx, &proto.ResolveNameRequest{Name: *name})
+		if err != nil {
+			log.Fatalf("Failed to resolve name: %v", err)
+		}
+		if !resp.Found {
+			fmt.Printf("Name %s is not registered\n", *name)
+			return
+		}
+		fmt.Printf("Name: %s\n", resp.Name)
+		fmt.Printf("  Address: %s\n", resp.Address)
+		fmt.Printf("  Expires after block: %d\n", resp.Expiry)
+
+	case "anchor":
+		var anchor *blockchain.Transaction
+		if *file != "" {
+			document, err := os.ReadFile(*file)
+			if err != nil {
+				log.Fatalf("Failed to read document: %v", err)
+			}
+			anchor, err = blockchain.NewAnchorTransaction(nil, document, *mimeType, *embed)
+			if err != nil {
+				log.Fatalf("Invalid anchor: %v", err)
+			}
+		} else {
+			hash, err := hex.DecodeString(*docHash)
+			if err != nil {
+				log.Fatalf("Invalid hash: %v", err)
+			}
+			anchor, err = blockchain.NewHashAnchorTransaction(nil, hash, *mimeType)
+			if err != nil {
+				log.Fatalf("Invalid anchor: %v", err)
+			}
+		}
+
+		resp := signAndSend(ctx, client, txSigner, anchor, *fee)
+		fmt.Printf("Anchor transaction sent: %s\n", resp.Message)
+
+	case "get-anchor":
+		hash := *docHash
+		if *file != "" {
+			document, err := os.ReadFile(*file)
+			if err != nil {
+				log.Fatalf("Failed to read document: %v", err)
+			}
+			sum := sha256.Sum256(document)
+			hash = hex.EncodeToString(sum[:])
+		}
+
+		resp, err := client.GetAnchor(ctx, &proto.GetAnchorRequest{Hash: hash})
+		if err != nil {
+			log.Fatalf("Failed to get anchor: %v", err)
+		}
+		if !resp.Found {
+			fmt.Printf("Hash %s has not been anchored\n", hash)
+			return
+		}
+
+		fmt.Printf("Anchor receipt for %s:\n", resp.Hash)
+		fmt.Printf("  Block: %d (%s)\n", resp.Height, resp.BlockHash)
+		fmt.Printf("  Timestamp: %s\n", time.Unix(resp.Timestamp, 0).UTC().Format(time.RFC3339))
+		if resp.ContentType != "" {
+			fmt.Printf("  Content type: %s\n", resp.ContentType)
+		}
+		fmt.Printf("  Merkle root: %s\n", resp.MerkleRoot)
+		fmt.Printf("  Proof steps: %d\n", len(resp.Proof))
+
+		receipt, err := receiptFromProto(resp)
+		if err != nil {
+			log.Fatalf("Invalid receipt: %v", err)
+		}
+		// The root is only as good as the block it came from: check the
+		// block hashes to the receipt's block hash over that root
+		blockResp, err := client.GetBlock(ctx, &proto.GetBlockRequest{Identifier: &proto.GetBlockRequest_Hash{Hash: resp.BlockHash}})
+		if err != nil {
+			log.Fatalf("Failed to get block %s: %v", resp.BlockHash, err)
+		}
+		block, err := protoconv.BlockFromProto(blockResp.Block)
+		if err != nil {
+			log.Fatalf("Invalid block %s: %v", resp.BlockHash, err)
+		}
+		if err := receipt.Verify(block); err != nil {
+			log.Fatalf("Receipt verification FAILED: %v", err)
+		}
+		fmt.Printf("  Receipt verified against block %d (%s)\n", resp.Height, resp.BlockHash)
+
+	case "approve":
+		// The sender allows the receiver to spend -amount of -asset; 0 revokes
+		op := blockchain.NewApproveTransaction(nil, mustResolveAddress(ctx, client, *receiver), *asset, *amount)
+		resp := signAndSend(ctx, client, txSigner, op, *fee)
+		fmt.Printf("Approve transaction sent: %s\n", resp.Message)
+
+	case "transfer-from":
+		// The sender spends the owner's allowance, paying the receiver
+		op, err := blockchain.NewTransferFromTransaction(nil, mustResolveAddress(ctx, client, *owner),
+			mustResolveAddress(ctx, client, *receiver), *asset, *amount)
+		if err != nil {
+			log.Fatalf("Invalid transfer-from: %v", err)
+		}
+
+		resp := signAndSend(ctx, client, txSigner, op, *fee)
+		fmt.Printf("Transfer-from transaction sent: %s\n", resp.Message)
+
+	case "allowance":
+		resp, err := client.GetAllowances(ctx, &proto.GetAllowancesRequest{
+			Owner:   mustResolve(ctx, client, *owner),
+			Spender: *spender,
+		})
+		if err != nil {
+			log.Fatalf("Failed to get allowances: %v", err)
+		}
+		fmt.Printf("Allowances granted by %s: %d\n", *owner, len(resp.Allowances))
+		for _, a := range resp.Allowances {
+			id := a.Asset
+			if id == "" {
+				id = blockchain.NativeAssetSymbol
+			}
+			fmt.Printf("  spender=%s asset=%s amount=%.2f\n", a.Spender, id, a.Amount)
+		}
+
+	case "grant":
+		// The sender gives the receiver -amount coins that unlock from -start to -end
+		op, err := blockchain.NewVestingGrantTransaction(nil, mustResolveAddress(ctx, client, *receiver),
+			*amount, *start, *cliff, *end)
+		if err != nil {
+			log.Fatalf("Invalid vesting grant: %v", err)
+		}
+
+		resp := signAndSend(ctx, client, txSigner, op, *fee)
+		fmt.Printf("Vesting grant sent: %s\n", resp.Message)
+
+	case "vesting":
+		resp, err := client.GetVesting(ctx, &proto.GetVestingRequest{
+			Address: mustResolve(ctx, client, *address),
+		})
+		if err != nil {
+			log.Fatalf("Failed to get vesting: %v", err)
+		}
+		fmt.Printf("Vesting for %s at height %d:\n", resp.Address, resp.Height)
+		fmt.Printf("  Balance:   %.2f\n", resp.Balance)
+		fmt.Printf("  Vested:    %.2f\n", resp.Vested)
+		fmt.Printf("  Locked:    %.2f\n", resp.Locked)
+		fmt.Printf("  Spendable: %.2f\n", resp.Spendable)
+		for _, schedule := range resp.Schedules {
+			fmt.Printf("  schedule start=%d cliff=%d end=%d total=%.2f vested=%.2f\n",
+				schedule.Start, schedule.Cliff, schedule.End, schedule.Total, schedule.Vested)
+		}
+
+	case "account":
+		resp := mustGetAccount(ctx, client, *address)
+		fmt.Printf("Account %s:\n", resp.Address)
+		fmt.Printf("  Chain ID:   %s\n", resp.ChainId)
+		fmt.Printf("  Next nonce: %d\n", resp.Nonce)
+		fmt.Printf("  Balance:    %.2f\n", resp.Balance)
+		fmt.Printf("  Min fee:    %.4f\n", resp.MinFee)
+		fmt.Printf("  Height:     %d\n", resp.Height)
+		if len(resp.PublicKey) > 0 {
+			fmt.Printf("  Key:        %s %x\n", resp.KeyScheme, resp.PublicKey)
+		}
+		if resp.RotatedAt > 0 {
+			fmt.Printf("  Rotated at: %d\n", resp.RotatedAt)
+		}
+
+	case "rotate-key":
+		// The current key hands the account over to a new one; the address stays the same
+		proof, err := newKey.Sign(blockchain.RotationDigest(txSigner.Address()))
+		if err != nil {
+			log.Fatalf("Failed to sign the rotation with the new key: %v", err)
+		}
+		rotate, err := blockchain.NewRotateKeyTransaction(nil, blockchain.KeyRotation{
+			Scheme:    newKey.Scheme(),
+			PublicKey: newKey.PublicKey(),
+			Proof:     proof,
+		})
+		if err != nil {
+			log.Fatalf("Invalid key rotation: %v", err)
+		}
+
+		resp := signAndSend(ctx, client, txSigner, rotate, *fee)
+		fmt.Printf("Key rotation sent: %s\n", resp.Message)
+
+		w := mustOpenWallet(*walletDir)
+		backup, err := w.ReplaceKey(*from, wallet.RotatedSigner(newKey, txSigner.Address()), newPassphrase)
+		if err != nil {
+			// The rotation is on its way; the new key must not be lost
+			secret, _ := wallet.PrivateKeyHex(newKey)
+			log.Fatalf("Failed to save the new key (%v); keep this %s private key: %s",
+				err, wallet.SchemeName(newKey.Scheme()), secret)
+		}
+		fmt.Printf("  %s now signs with a new %s key\n", wallet.FormatAddress(txSigner.Address()), wallet.SchemeName(newKey.Scheme()))
+		fmt.Printf("  Previous key kept in %s in case the rotation is not committed\n", backup)
+
+	case "build":
+		// Online step: an unsigned transfer filled in with the node's chain ID and nonce
+		if *outFile == "" {
+			log.Fatalf("build writes an unsigned transaction; pass the output file with -out")
+		}
+		account := mustGetAccount(ctx, client, buildSender(*walletDir, *from))
+		sender, err := wallet.ParseAddress(account.Address)
+		if err != nil {
+			log.Fatalf("Invalid sender: %v", err)
+		}
+		if *fee < account.MinFee {
+			log.Fatalf("Fee %.4f is below the node's minimum fee %.4f", *fee, account.MinFee)
+		}
+
+		tx := &blockchain.Transaction{
+			Sender:    sender,
+			Receiver:  mustResolveAddress(ctx, client, *receiver),
+			Amount:    *amount,
+			Timestamp: time.Now().Unix(),
+			Asset:     *asset,
+			Fee:       *fee,
+			Nonce:     account.Nonce,
+			ChainID:   account.ChainId,
+		}
+		if *nonce != 0 {
+			tx.Nonce = *nonce
+		}
+		if *memo != "" {
+			tx.Memo = mustEncryptMemo(ctx, client, tx.Receiver, *memo)
+		}
+		if err := wallet.SaveTxFile(*outFile, wallet.NewTxFile(tx)); err != nil {
+			log.Fatalf("Failed to save transaction: %v", err)
+		}
+		fmt.Printf("Unsigned transaction written to %s\n", *outFile)
+		printTxFile(wallet.NewTxFile(tx))
+
+	case "sign":
+		// Offline step: needs only the key, never contacts the node
+		txFile := mustLoadTxFile(*file)
+		printTxFile(txFile)
+		tx, err := txFile.Transaction()
+		if err != nil {
+			log.Fatalf("Invalid transaction file: %v", err)
+		}
+		if !wallet.Address(tx.Sender).Equal(txSigner.Address()) {
+			log.Fatalf("Transaction is from %s but the key is for %s", txFile.From, wallet.FormatAddress(txSigner.Address()))
+		}
+		if err := txSigner.SignTransaction(ctx, tx); err != nil {
+			log.Fatalf("Failed to sign transaction: %v", err)
+		}
+		txFile = wallet.NewTxFile(tx)
+
+		out := *outFile
+		if out == "" {
+			out = *file
+		}
+		if err := wallet.SaveTxFile(out, txFile); err != nil {
+			log.Fatalf("Failed to save transaction: %v", err)
+		}
+		fmt.Printf("Signed transaction written to %s\n", out)
+
+	case "broadcast":
+		txFile := mustLoadTxFile(*file)
+		if !txFile.Signed() {
+			log.Fatalf("%s is not signed; run -cmd=sign first", *file)
+		}
+		tx, err := txFile.Transaction()
+		if err != nil {
+			log.Fatalf("Invalid transaction file: %v", err)
+		}
+
+		resp := send(ctx, client, tx)
+		fmt.Printf("Transaction broadcast: %s\n", resp.Message)
+		printTxFile(txFile)
+
+	case "scan":
+		// Bring the wallet's history up to date from the node
+		w := mustOpenWallet(*walletDir)
+		accounts, err := w.Accounts()
+		if err != nil {
+			log.Fatalf("Failed to list accounts: %v", err)
+		}
+		scanner, err := wallet.LoadScanner(w.ScanPath())
+		if err != nil {
+			log.Fatalf("Failed to load scan state: %v", err)
+		}
+		addresses := make([][]byte, 0, len(accounts))
+		for _, account := range accounts {
+			addresses = append(addresses, account.Address)
+		}
+		scanner.SetAddresses(addresses)
+
+		scanned, err := scanner.Scan(ctx, wallet.NodeBlocks(client))
+		if err != nil {
+			log.Fatalf("Scan failed after %d blocks: %v", scanned, err)
+		}
+		fmt.Printf("Scanned %d block(s), now at height %d\n", scanned, scanner.Height())
+		for _, account := range accounts {
+			history := scanner.History(account.Address)
+			fmt.Printf("  %-16s %s  %d tx", account.Name, account.Address, len(history.Activity))
+			for _, asset := range wallet.SortedAssets(history.Balances) {
+				id := asset
+				if id == "" {
+					id = blockchain.NativeAssetSymbol
+				}
+				fmt.Printf("  %.8f %s", history.Balances[asset], id)
+			}
+			fmt.Println()
+		}
+
+	case "tx":
+		if *docHash == "" {
+			log.Fatalf("Usage: cli tx show <hash>")
+		}
+		resp, err := client.GetTransaction(ctx, &proto.GetTransactionRequest{Hash: *docHash})
+		if err != nil {
+			log.Fatalf("Failed to get transaction: %v", err)
+		}
+		if !resp.Found {
+			log.Fatalf("Transaction %s not found", *docHash)
+		}
+		printTransaction(resp.Transaction)
+		if resp.Pending {
+			fmt.Printf("  Status: pending, not yet in a block\n")
+			break
+		}
+		fmt.Printf("  Block:  %d (%s), position %d\n", resp.BlockHeight, resp.BlockHash[:16]+"...", resp.Position)
+		fmt.Printf("  Time:   %s\n", time.Unix(resp.BlockTimestamp, 0).Format(time.RFC3339))
+		fmt.Printf("  Confirmations: %d\n", resp.Confirmations)
+
+	case "history":
+		resp, err := client.GetAddressHistory(ctx, &proto.GetAddressHistoryRequest{
+			Address:     mustResolve(ctx, client, *address),
+			Direction:   *direction,
+			Limit:       uint32(*limit),
+			Cursor:      *cursor,
+			OldestFirst: *oldestFirst,
+		})
+		if err != nil {
+			log.Fatalf("Failed to get history: %v", err)
+		}
+		fmt.Printf("History of %s:\n", resp.Address)
+		if len(resp.Entries) == 0 {
+			fmt.Println("  No transactions")
+		}
+		for _, entry := range resp.Entries {
+			tx := entry.Transaction
+			asset := tx.Asset
+			if asset == "" {
+				asset = blockchain.NativeAssetSymbol
+			}
+			fmt.Printf("  %6d:%-3d %s %-4s %s  %s -> %s: %.2f %s (fee %.4f)\n",
+				entry.BlockHeight, entry.Position, time.Unix(entry.BlockTimestamp, 0).Format("2006-01-02 15:04"),
+				historyDirection(entry), entry.TxHash[:16], hexAddress(tx.Sender), hexAddress(tx.Receiver), tx.Amount, asset, tx.Fee)
+		}
+		if resp.NextCursor != "" {
+			fmt.Printf("More: -cursor=%s\n", resp.NextCursor)
+		}
 
 	default:
 		fmt.Printf("Unknown command: %s\n", *command)
-		fmt.Println("Available commands: latest, send")
+		fmt.Println("Available commands: latest, send, issue, assets, balance, register-name, renew-name, transfer-name, lookup, anchor, get-anchor, approve, transfer-from, allowance, grant, vesting, account, rotate-key, build, sign, broadcast, scan, tx, history")
+	}
+}
+
+// txCommands are the commands that sign a transaction as -from (or -key)
+var txCommands = map[string]bool{
+	"send": true, "issue": true, "register-name": true, "renew-name": true,
+	"transfer-name": true, "anchor": true, "approve": true, "transfer-from": true,
+	"grant": true, "sign": true, "rotate-key": true,
+}
+
+// mustPrepareRotation generates the key a wallet account is rotated to and
+// asks for the passphrase that will protect it
+func mustPrepareRotation(key, socket, schemeName string) (wallet.Signer, string) {
+	if key != "" || socket != "" {
+		log.Fatalf("rotate-key replaces the key of a wallet account; pass the account with -from")
+	}
+	scheme, err := wallet.ParseScheme(schemeName)
+	if err != nil {
+		log.Fatalf("Invalid -scheme: %v", err)
+	}
+	newKey, err := wallet.GenerateSigner(scheme)
+	if err != nil {
+		log.Fatalf("Failed to generate key: %v", err)
+	}
+	passphrase, err := wallet.ReadPassphrase("Passphrase for the new key: ", true)
+	if err != nil {
+		log.Fatalf("Failed to read passphrase: %v", err)
+	}
+	return newKey, passphrase
+}
+
+// mustLoadSigner returns the sender's signer: a key of the signer daemon when
+// a socket is given, the key file given with -key, or otherwise the wallet
+// account named by -from (the default account if empty), unlocked with its
+// passphrase
+func mustLoadSigner(walletDir, from, key, socket string) signer.TransactionSigner {
+	if socket != "" {
+		if key == "" {
+			key = from
+		}
+		if key == "" {
+			log.Fatalf("Pass the name of the signer's key with -key")
+		}
+		remote, err := signer.DialKey(socket, key)
+		if err != nil {
+			log.Fatalf("Failed to reach signer: %v", err)
+		}
+		return remote
+	}
+
+	path := key
+	if path == "" {
+		w := mustOpenWallet(walletDir)
+		account, err := w.Account(from)
+		if err != nil {
+			log.Fatalf("This command signs a transaction; pass a wallet account with -from (or a key file with -key): %v", err)
+		}
+		if account.WatchOnly {
+			log.Fatalf("Account %s is watch-only and cannot sign", account.Name)
+		}
+		from, path = account.Name, w.KeyPath(account.Name)
+	} else {
+		from = key
+	}
+
+	passphrase, err := wallet.ReadPassphrase(fmt.Sprintf("Passphrase for %s: ", from), false)
+	if err != nil {
+		log.Fatalf("Failed to read passphrase: %v", err)
+	}
+	local, err := wallet.LoadSigner(path, passphrase)
+	if err != nil {
+		log.Fatalf("Failed to load key: %v", err)
+	}
+	return signer.Local(local)
+}
+
+// buildSender returns the sender of a build: the address of a wallet account
+// (the default one if from is empty), or from itself as an address or name
+func buildSender(walletDir, from string) string {
+	if from != "" && wallet.LooksLikeAddress(from) {
+		return from
+	}
+	w := mustOpenWallet(walletDir)
+	if from != "" && !w.Has(from) {
+		return from // A registered name, resolved by the node
+	}
+	account, err := w.Account(from)
+	if err != nil {
+		log.Fatalf("Pass the sender with -from: %v", err)
+	}
+	return account.Address.String()
+}
+
+// mustOpenWallet opens the wallet directory named by -wallet
+func mustOpenWallet(dir string) *wallet.Wallet {
+	w, err := wallet.OpenWallet(dir)
+	if err != nil {
+		log.Fatalf("Failed to open wallet: %v", err)
+	}
+	return w
+}
+
+// signAndSend sets the sender, fee, nonce and chain ID of a transaction,
+// signs it and submits it
+func signAndSend(ctx context.Context, client proto.BlockchainServiceClient, txSigner signer.TransactionSigner, tx *blockchain.Transaction, fee float64) *proto.SendTransactionResponse {
+	account := mustGetAccount(ctx, client, wallet.FormatAddress(txSigner.Address()))
+	tx.Sender = txSigner.Address()
+	tx.Fee = fee
+	tx.Nonce = account.Nonce
+	tx.ChainID = account.ChainId
+	if err := txSigner.SignTransaction(ctx, tx); err != nil {
+		log.Fatalf("Failed to sign transaction: %v", err)
+	}
+	return send(ctx, client, tx)
+}
+
+// send submits a signed transaction, exiting if the node rejects it
+func send(ctx context.Context, client proto.BlockchainServiceClient, tx *blockchain.Transaction) *proto.SendTransactionResponse {
+	resp, err := client.SendTransaction(ctx, &proto.SendTransactionRequest{
+		Transaction: protoconv.TransactionToProto(tx),
+	})
+	if err != nil {
+		log.Fatalf("Failed to send %s transaction: %v", txLabel(tx.Type), err)
+	}
+	if !resp.Accepted {
+		log.Fatalf("Transaction rejected: %s", resp.Message)
+	}
+	return resp
+}
+
+// mustGetAccount fetches the chain ID, next nonce and fee policy for an account
+func mustGetAccount(ctx context.Context, client proto.BlockchainServiceClient, account string) *proto.GetAccountResponse {
+	resp, err := client.GetAccount(ctx, &proto.GetAccountRequest{Address: account})
+	if err != nil {
+		log.Fatalf("Failed to get account %q: %v", account, err)
+	}
+	return resp
+}
+
+// mustEncryptMemo encrypts a memo to the key the node knows for the receiver
+func mustEncryptMemo(ctx context.Context, client proto.BlockchainServiceClient, receiver []byte, memo string) []byte {
+	account := mustGetAccount(ctx, client, wallet.FormatAddress(receiver))
+	if len(account.PublicKey) == 0 {
+		log.Fatalf("The key of %s is not known until it sends a transaction; cannot encrypt a memo to it", account.Address)
+	}
+	scheme, err := wallet.ParseScheme(account.KeyScheme)
+	if err != nil {
+		log.Fatalf("Receiver key: %v", err)
+	}
+	sealed, err := wallet.EncryptMemo(scheme, account.PublicKey, []byte(memo))
+	if err != nil {
+		log.Fatalf("Failed to encrypt memo: %v", err)
+	}
+	return sealed
+}
+
+// mustLoadTxFile reads the transaction file named by -file
+func mustLoadTxFile(path string) *wallet.TxFile {
+	if path == "" {
+		log.Fatalf("Pass the transaction file with -file")
+	}
+	txFile, err := wallet.LoadTxFile(path)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	return txFile
+}
+
+// printTxFile shows what a transaction file does, for review before signing
+func printTxFile(f *wallet.TxFile) {
+	asset := f.Asset
+	if asset == "" {
+		asset = blockchain.NativeAssetSymbol
+	}
+	if f.Type != "" {
+		fmt.Printf("  Type: %s\n", f.Type)
+	}
+	fmt.Printf("  %s -> %s: %.2f %s (fee %.4f)\n", f.From, f.To, f.Amount, asset, f.Fee)
+	if f.Memo != "" {
+		fmt.Printf("  Encrypted memo: %d bytes\n", len(f.Memo)/2)
+	}
+	fmt.Printf("  Chain ID: %s, nonce: %d, signed: %t\n", f.ChainID, f.Nonce, f.Signed())
+}
+
+// printTransaction prints a transaction returned by the node
+func printTransaction(tx *proto.Transaction) {
+	asset := tx.Asset
+	if asset == "" {
+		asset = blockchain.NativeAssetSymbol
+	}
+	fmt.Printf("Transaction:\n")
+	fmt.Printf("  Type:   %s\n", txLabel(blockchain.TxType(tx.Type)))
+	fmt.Printf("  %s -> %s: %.2f %s (fee %.4f)\n", hexAddress(tx.Sender), hexAddress(tx.Receiver), tx.Amount, asset, tx.Fee)
+	if tx.Nonce > 0 {
+		fmt.Printf("  Nonce:  %d\n", tx.Nonce)
+	}
+	if len(tx.Memo) > 0 {
+		fmt.Printf("  Encrypted memo: %d bytes\n", len(tx.Memo))
+	}
+}
+
+// historyDirection labels a history entry from the queried address' side
+func historyDirection(entry *proto.AddressHistoryEntry) string {
+	switch {
+	case entry.Sent && entry.Received:
+		return "self"
+	case entry.Sent:
+		return "out"
+	default:
+		return "in"
+	}
+}
+
+// hexAddress formats a hex-encoded address from the node, "-" for none
+func hexAddress(h string) string {
+	address, err := hex.DecodeString(h)
+	if err != nil || len(address) == 0 {
+		return "-"
+	}
+	if (&blockchain.Transaction{Sender: address}).IsCoinbase() {
+		return string(address) // System senders are readable markers
+	}
+	return wallet.FormatAddress(address)
+}
+
+// txLabel returns a printable name for a transaction type
+func txLabel(txType blockchain.TxType) string {
+	if txType == blockchain.TxTransfer {
+		return "transfer"
+	}
+	return string(txType)
+}
+
+// receiptFromProto rebuilds an anchor receipt so it can be verified locally
+func receiptFromProto(resp *proto.GetAnchorResponse) (*blockchain.AnchorReceipt, error) {
+	tx, err := protoconv.TransactionFromProto(resp.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	hash, _ := hex.DecodeString(resp.Hash)
+	blockHash, _ := hex.DecodeString(resp.BlockHash)
+	root, _ := hex.DecodeString(resp.MerkleRoot)
+
+	receipt := &blockchain.AnchorReceipt{
+		Hash:        hash,
+		Height:      int(resp.Height),
+		BlockHash:   blockHash,
+		MerkleRoot:  root,
+		TxIndex:     int(resp.TxIndex),
+		Transaction: tx,
+	}
+	for _, step := range resp.Proof {
+		stepHash, _ := hex.DecodeString(step.Hash)
+		receipt.Proof = append(receipt.Proof, blockchain.MerkleProofStep{Hash: stepHash, Left: step.Left})
+	}
+	return receipt, nil
+}
+
+// mustResolve is mustResolveAddress returning the encoded address
+func mustResolve(ctx context.Context, client proto.BlockchainServiceClient, account string) string {
+	return wallet.FormatAddress(mustResolveAddress(ctx, client, account))
+}
+
+// mustResolveAddress turns an address or a registered name into an address.
+// Addresses are checked locally, so a typo fails before anything is sent.
+func mustResolveAddress(ctx context.Context, client proto.BlockchainServiceClient, account string) []byte {
+	if wallet.LooksLikeAddress(account) {
+		address, err := wallet.ParseAddress(account)
+		if err != nil {
+			log.Fatalf("Invalid address: %v", err)
+		}
+		return address
+	}
+
+	resp, err := client.ResolveName(ctx, &proto.ResolveNameRequest{Name: account})
+	if err != nil {
+		log.Fatalf("Failed to resolve %q: %v", account, err)
+	}
+	if !resp.Found {
+		log.Fatalf("%q is not a registered name; pass an address instead", account)
+	}
+	address, err := wallet.ParseAddress(resp.Address)
+	if err != nil {
+		log.Fatalf("Invalid address for %q: %v", account, err)
 	}
+	return address
 }
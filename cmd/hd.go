@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+)
+
+// hdWalletFile holds the encrypted seed phrase and derived addresses
+const hdWalletFile = "hd_wallet.json"
+
+// createHDWallet generates a new seed phrase and saves it encrypted
+func createHDWallet() {
+	if _, err := os.Stat(hdWalletFile); err == nil {
+		fmt.Printf("❌ %s already exists; remove it first to create a new wallet\n", hdWalletFile)
+		return
+	}
+
+	mnemonic, err := wallet.NewMnemonic()
+	if err != nil {
+		fmt.Printf("Error generating seed phrase: %v\n", err)
+		return
+	}
+	if !saveHDWallet(mnemonic) {
+		return
+	}
+
+	fmt.Println("\n📝 Write down your seed phrase and keep it offline.")
+	fmt.Println("   It is the only way to recover every address of this wallet:")
+	fmt.Printf("\n   %s\n\n", mnemonic)
+}
+
+// recoverHDWallet rebuilds a wallet from an existing seed phrase
+func recoverHDWallet() {
+	if _, err := os.Stat(hdWalletFile); err == nil {
+		fmt.Printf("❌ %s already exists; remove it first to recover a wallet\n", hdWalletFile)
+		return
+	}
+
+	mnemonic, err := wallet.PromptLine("Seed phrase: ")
+	if err != nil {
+		fmt.Printf("Error reading seed phrase: %v\n", err)
+		return
+	}
+	saveHDWallet(mnemonic)
+}
+
+func saveHDWallet(mnemonic string) bool {
+	passphrase, err := wallet.ReadPassphrase(fmt.Sprintf("New passphrase for %s: ", hdWalletFile), true)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return false
+	}
+
+	w, err := wallet.NewHDWalletFile(mnemonic, passphrase)
+	if err != nil {
+		fmt.Printf("Error creating HD wallet: %v\n", err)
+		return false
+	}
+	if err := wallet.SaveHDWallet(hdWalletFile, w); err != nil {
+		fmt.Printf("Error saving HD wallet: %v\n", err)
+		return false
+	}
+
+	fmt.Printf("✅ HD wallet saved to %s\n", hdWalletFile)
+	fmt.Printf("Address 0 (%s/0): %s\n", w.Path, displayAddress(w.Addresses[0]))
+	return true
+}
+
+// deriveHDAddresses derives the next addresses of the wallet
+func deriveHDAddresses(args []string) {
+	count := 1
+	if len(args) > 2 {
+		n, err := strconv.Atoi(args[2])
+		if err != nil || n < 1 {
+			fmt.Println("Usage: cli hd-derive [count]")
+			return
+		}
+		count = n
+	}
+
+	w, mnemonic, ok := unlockHDWallet()
+	if !ok {
+		return
+	}
+	for i := 0; i < count; i++ {
+		address, err := w.DeriveNext(mnemonic)
+		if err != nil {
+			fmt.Printf("Error deriving address: %v\n", err)
+			return
+		}
+		index := len(w.Addresses) - 1
+		fmt.Printf("Address %d (%s): %s\n", index, wallet.AccountPath(w.Path, index), wallet.FormatAddress(address))
+	}
+	if err := wallet.SaveHDWallet(hdWalletFile, w); err != nil {
+		fmt.Printf("Error saving HD wallet: %v\n", err)
+	}
+}
+
+// listHDAddresses prints the derived addresses; no passphrase is needed
+func listHDAddresses() {
+	w, err := wallet.LoadHDWallet(hdWalletFile)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		fmt.Println("💡 Run 'cli hd-create' or 'cli hd-recover' first")
+		return
+	}
+
+	fmt.Printf("🔑 HD wallet %s (%d addresses):\n", hdWalletFile, len(w.Addresses))
+	for i, address := range w.Addresses {
+		fmt.Printf("  %d  %s  %s\n", i, wallet.AccountPath(w.Path, i), displayAddress(address))
+	}
+}
+
+// exportHDKey writes the key of a derived address to an encrypted key file,
+// so it can be used by the commands that take a key file
+func exportHDKey(args []string) {
+	if len(args) < 4 {
+		fmt.Println("Usage: cli hd-export <index> <key_file>")
+		return
+	}
+	index, err := strconv.Atoi(args[2])
+	if err != nil || index < 0 {
+		fmt.Printf("Invalid index: %s\n", args[2])
+		return
+	}
+
+	w, mnemonic, ok := unlockHDWallet()
+	if !ok {
+		return
+	}
+	priv, err := w.Key(mnemonic, index)
+	if err != nil {
+		fmt.Printf("Error deriving key: %v\n", err)
+		return
+	}
+	if err := saveKeyWithName(priv, args[3]); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Address %d: %s\n", index, wallet.FormatAddress(wallet.PublicKeyToAddress(&priv.PublicKey)))
+}
+
+// displayAddress formats a hex address as stored in wallet files
+func displayAddress(hexAddress string) string {
+	address, err := wallet.ParseAddress(hexAddress)
+	if err != nil {
+		return hexAddress
+	}
+	return address.String()
+}
+
+func unlockHDWallet() (*wallet.HDWalletFile, string, bool) {
+	w, err := wallet.LoadHDWallet(hdWalletFile)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		fmt.Println("💡 Run 'cli hd-create' or 'cli hd-recover' first")
+		return nil, "", false
+	}
+
+	passphrase, err := wallet.ReadPassphrase(fmt.Sprintf("Passphrase for %s: ", hdWalletFile), false)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return nil, "", false
+	}
+	mnemonic, err := w.Mnemonic(passphrase)
+	if err != nil {
+		fmt.Printf("Error unlocking HD wallet: %v\n", err)
+		return nil, "", false
+	}
+	return w, mnemonic, true
+}
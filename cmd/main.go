@@ -2,14 +2,11 @@ package main
 
 import (
 	"crypto/ecdsa"
-	"crypto/elliptic"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"log"
-	"math/big"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
@@ -17,158 +14,83 @@ import (
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
 )
 
-type KeyData struct {
-	PrivateKey string `json:"private_key"`
-	PublicKeyX string `json:"public_key_x"`
-	PublicKeyY string `json:"public_key_y"`
-}
-
-func saveKey(priv *ecdsa.PrivateKey) error {
-	keyData := KeyData{
-		PrivateKey: hex.EncodeToString(priv.D.Bytes()),
-		PublicKeyX: hex.EncodeToString(priv.PublicKey.X.Bytes()),
-		PublicKeyY: hex.EncodeToString(priv.PublicKey.Y.Bytes()),
-	}
-
-	f, err := os.Create("user_key.json")
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer f.Close()
-
-	if err := json.NewEncoder(f).Encode(keyData); err != nil {
-		return fmt.Errorf("failed to encode key: %w", err)
-	}
-
-	fmt.Println("Saved private key to user_key.json")
-	return nil
-}
-
-func loadKey() (*ecdsa.PrivateKey, error) {
-	f, err := os.Open("user_key.json")
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer f.Close()
-
-	var keyData KeyData
-	if err := json.NewDecoder(f).Decode(&keyData); err != nil {
-		return nil, fmt.Errorf("failed to decode key: %w", err)
-	}
-
-	// Decode private key
-	privKeyBytes, err := hex.DecodeString(keyData.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode private key: %w", err)
-	}
-
-	// Decode public key coordinates
-	pubKeyXBytes, err := hex.DecodeString(keyData.PublicKeyX)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode public key X: %w", err)
-	}
-
-	pubKeyYBytes, err := hex.DecodeString(keyData.PublicKeyY)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode public key Y: %w", err)
-	}
-
-	// Reconstruct the private key
-	priv := &ecdsa.PrivateKey{
-		D: new(big.Int).SetBytes(privKeyBytes),
-		PublicKey: ecdsa.PublicKey{
-			Curve: elliptic.P256(),
-			X:     new(big.Int).SetBytes(pubKeyXBytes),
-			Y:     new(big.Int).SetBytes(pubKeyYBytes),
-		},
-	}
-
-	return priv, nil
-}
-
+// saveKeyWithName encrypts a key into an encrypted keystore file, asking for
+// a new passphrase
 func saveKeyWithName(priv *ecdsa.PrivateKey, filename string) error {
-	keyData := KeyData{
-		PrivateKey: hex.EncodeToString(priv.D.Bytes()),
-		PublicKeyX: hex.EncodeToString(priv.PublicKey.X.Bytes()),
-		PublicKeyY: hex.EncodeToString(priv.PublicKey.Y.Bytes()),
-	}
-
-	f, err := os.Create(filename)
+	passphrase, err := wallet.ReadPassphrase(fmt.Sprintf("New passphrase for %s: ", filename), true)
 	if err != nil {
-		return fmt.Errorf("failed to create file %s: %w", filename, err)
+		return err
 	}
-	defer f.Close()
 
-	if err := json.NewEncoder(f).Encode(keyData); err != nil {
-		return fmt.Errorf("failed to encode key: %w", err)
+	if err := wallet.SaveKeystore(filename, priv, passphrase); err != nil {
+		return fmt.Errorf("failed to save key %s: %w", filename, err)
 	}
 
-	fmt.Printf("💾 Saved private key to %s\n", filename)
+	fmt.Printf("💾 Saved encrypted private key to %s\n", filename)
 	return nil
 }
 
-func loadKeyFromFile(filename string) (*ecdsa.PrivateKey, error) {
-	f, err := os.Open(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file %s: %w", filename, err)
-	}
-	defer f.Close()
-
-	var keyData KeyData
-	if err := json.NewDecoder(f).Decode(&keyData); err != nil {
-		return nil, fmt.Errorf("failed to decode key: %w", err)
-	}
-
-	// Decode private key
-	privKeyBytes, err := hex.DecodeString(keyData.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode private key: %w", err)
-	}
-
-	// Decode public key coordinates
-	pubKeyXBytes, err := hex.DecodeString(keyData.PublicKeyX)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode public key X: %w", err)
-	}
-
-	pubKeyYBytes, err := hex.DecodeString(keyData.PublicKeyY)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode public key Y: %w", err)
-	}
-
-	// Reconstruct the private key
-	priv := &ecdsa.PrivateKey{
-		D: new(big.Int).SetBytes(privKeyBytes),
-		PublicKey: ecdsa.PublicKey{
-			Curve: elliptic.P256(),
-			X:     new(big.Int).SetBytes(pubKeyXBytes),
-			Y:     new(big.Int).SetBytes(pubKeyYBytes),
-		},
-	}
-
-	return priv, nil
-}
-
 func main() {
 	args := os.Args
 	if len(args) < 2 {
 		printUsage()
 		return
 	}
+	if err := wallet.UseNetworkEnv(); err != nil {
+		log.Fatal(err)
+	}
 
 	switch args[1] {
-	case "create-alice":
-		createAlice()
-	case "create-bob":
-		createBob()
-	case "alice-to-bob":
-		aliceToBobTransaction(args)
+	case "send":
+		sendTransaction(args)
 	case "demo":
 		runAliceBobDemo()
 	case "init":
 		initBlockchain()
 	case "test":
 		runFullTest()
+	case "create-key":
+		createKey(args)
+	case "migrate-keys":
+		migrateKeys(args)
+	case "account-create":
+		createAccount(args)
+	case "account-list":
+		listAccounts()
+	case "account-rename":
+		renameAccount(args)
+	case "account-import":
+		importAccount(args)
+	case "account-export":
+		exportAccount(args)
+	case "account-default":
+		setDefaultAccount(args)
+	case "sign-message":
+		signMessage(args)
+	case "verify-message":
+		verifyMessage(args)
+	case "watch-add":
+		watchAddress(args)
+	case "watch-remove":
+		unwatchAddress(args)
+	case "scan":
+		scanLocal(args)
+	case "reindex":
+		reindexLocal(args)
+	case "migrate":
+		migrateLocal(args)
+	case "activity":
+		printActivity(args)
+	case "hd-create":
+		createHDWallet()
+	case "hd-recover":
+		recoverHDWallet()
+	case "hd-derive":
+		deriveHDAddresses(args)
+	case "hd-list":
+		listHDAddresses()
+	case "hd-export":
+		exportHDKey(args)
 	case "help":
 		printUsage()
 	default:
@@ -179,173 +101,59 @@ func main() {
 
 func printUsage() {
 	fmt.Println("🚀 Blockchain CLI Usage:")
-	fmt.Println("  create-alice         - Create Alice's wallet with ECDSA keys")
-	fmt.Println("  create-bob           - Create Bob's wallet with ECDSA keys")
-	fmt.Println("  alice-to-bob <amount> - Send money from Alice to Bob")
+	fmt.Println("  send <to> <amount> [--from <account>] - Send coins from a wallet account")
 	fmt.Println("  demo                 - Run complete Alice & Bob demo")
 	fmt.Println("  test                 - Run full system test")
 	fmt.Println("  init                 - Initialize blockchain")
+	fmt.Println("  create-key <file> [scheme] - Create a key file (scheme: p256 or ed25519)")
+	fmt.Println("  migrate-keys [files] - Encrypt plaintext key files (default: user, alice, bob)")
+	fmt.Println("  account-create <name> [scheme] - Add a new account to the wallet")
+	fmt.Println("  account-list         - List the wallet's accounts (* marks the default)")
+	fmt.Println("  account-rename <name> <new-name> - Rename an account")
+	fmt.Println("  account-import <name> <file|hex> [scheme] - Import a key file or hex private key")
+	fmt.Println("  account-export <name> <file|--hex> - Export an account's key")
+	fmt.Println("  account-default <name> - Use an account when --from is not given")
+	fmt.Println("  sign-message <msg|@file> [--from <account>] - Sign a message to prove you own an address")
+	fmt.Println("  verify-message <msg|@file> <signature> [--node <addr>] - Check a signature from sign-message against a node")
+	fmt.Println("  watch-add <name> <address> - Track an address whose key you don't hold")
+	fmt.Println("  watch-remove <name>  - Stop tracking a watched address")
+	fmt.Println("  scan <data-dir>      - Scan a stopped node's chain store for the wallet's accounts")
+	fmt.Println("  activity [account] [--memos] - Print scanned balances and history (--memos decrypts incoming memos)")
+	fmt.Println("  reindex <data-dir>   - Rebuild a stopped node's block, transaction and address indexes")
+	fmt.Println("  migrate <data-dir> [--dry-run] - Show and run a stopped node's pending storage migrations")
+	fmt.Println("  hd-create            - Create an HD wallet with a new seed phrase")
+	fmt.Println("  hd-recover           - Recover an HD wallet from its seed phrase")
+	fmt.Println("  hd-derive [count]    - Derive the next address(es) of the HD wallet")
+	fmt.Println("  hd-list              - List the derived HD wallet addresses")
+	fmt.Println("  hd-export <i> <file> - Save the key of HD address i to a key file")
 	fmt.Println("  help                 - Show this help message")
-}
-func createUserKey() {
-	priv, err := wallet.GenerateKeyPair()
-	if err != nil {
-		fmt.Printf("Error generating key pair: %v\n", err)
-		return
-	}
-
-	if err := saveKey(priv); err != nil {
-		fmt.Printf("Error saving key: %v\n", err)
-		return
-	}
-
-	address := wallet.PublicKeyToAddress(&priv.PublicKey)
-	fmt.Printf("✅ Wallet created successfully!\n")
-	fmt.Printf("Address: %x\n", address)
-}
-
-func createAlice() {
-	fmt.Println("👩 Creating Alice's wallet...")
-
-	alicePriv, err := wallet.GenerateKeyPair()
-	if err != nil {
-		fmt.Printf("Error generating Alice's key pair: %v\n", err)
-		return
-	}
-
-	if err := saveKeyWithName(alicePriv, "alice_key.json"); err != nil {
-		fmt.Printf("Error saving Alice's key: %v\n", err)
-		return
-	}
-
-	aliceAddr := wallet.PublicKeyToAddress(&alicePriv.PublicKey)
-	fmt.Printf("✅ Alice's wallet created successfully!\n")
-	fmt.Printf("Alice Address: %x\n", aliceAddr)
-	fmt.Printf("💾 Keys saved to: alice_key.json\n")
-}
-
-func createBob() {
-	fmt.Println("👨 Creating Bob's wallet...")
-
-	bobPriv, err := wallet.GenerateKeyPair()
-	if err != nil {
-		fmt.Printf("Error generating Bob's key pair: %v\n", err)
-		return
-	}
-
-	if err := saveKeyWithName(bobPriv, "bob_key.json"); err != nil {
-		fmt.Printf("Error saving Bob's key: %v\n", err)
-		return
-	}
-
-	bobAddr := wallet.PublicKeyToAddress(&bobPriv.PublicKey)
-	fmt.Printf("✅ Bob's wallet created successfully!\n")
-	fmt.Printf("Bob Address: %x\n", bobAddr)
-	fmt.Printf("💾 Keys saved to: bob_key.json\n")
-}
-
-func aliceToBobTransaction(args []string) {
-	if len(args) < 3 {
-		fmt.Println("Usage: cli alice-to-bob <amount>")
-		fmt.Println("Example: cli alice-to-bob 25.5")
-		return
-	}
-
-	// Parse amount
-	amount, err := strconv.ParseFloat(args[2], 64)
-	if err != nil {
-		fmt.Printf("Invalid amount: %v\n", err)
-		return
-	}
-
-	// Load Alice's key
-	fmt.Println("🔑 Loading Alice's key...")
-	alicePriv, err := loadKeyFromFile("alice_key.json")
-	if err != nil {
-		fmt.Printf("Error loading Alice's key: %v\n", err)
-		fmt.Println("💡 Please run 'cli create-alice' first to create Alice's wallet")
-		return
-	}
-
-	// Load Bob's key to get his address
-	fmt.Println("🔑 Loading Bob's address...")
-	bobPriv, err := loadKeyFromFile("bob_key.json")
-	if err != nil {
-		fmt.Printf("Error loading Bob's key: %v\n", err)
-		fmt.Println("💡 Please run 'cli create-bob' first to create Bob's wallet")
-		return
-	}
-
-	aliceAddr := wallet.PublicKeyToAddress(&alicePriv.PublicKey)
-	bobAddr := wallet.PublicKeyToAddress(&bobPriv.PublicKey)
-
-	fmt.Printf("💸 Alice (%x) sending %.2f coins to Bob (%x)...\n",
-		aliceAddr[:8], amount, bobAddr[:8])
-
-	// Create transaction
-	tx := &blockchain.Transaction{
-		Sender:    aliceAddr,
-		Receiver:  bobAddr,
-		Amount:    amount,
-		Timestamp: time.Now().Unix(),
-	}
-
-	// Alice signs the transaction
-	fmt.Println("🔏 Alice signing transaction...")
-	if err := wallet.SignTransaction(tx, alicePriv); err != nil {
-		fmt.Printf("Error signing transaction: %v\n", err)
-		return
-	}
-
-	// Verify signature
-	if !wallet.VerifyTransaction(tx, &alicePriv.PublicKey) {
-		fmt.Println("❌ Transaction signature invalid")
-		return
-	}
-	fmt.Println("✅ Transaction signature verified")
-	// Create validator and process transaction
-	fmt.Println("📦 Creating block...")
-	validator, err := validator.NewValidatorNodeLegacy("./blockchain_data")
-	if err != nil {
-		fmt.Printf("Error creating validator: %v\n", err)
-		return
-	}
-	defer validator.CloseLegacy()
-
-	block, err := validator.CreateBlock([]*blockchain.Transaction{tx})
-	if err != nil {
-		fmt.Printf("Error creating block: %v\n", err)
-		return
-	}
-
-	fmt.Println("\n🎉 Transaction completed successfully!")
-	fmt.Printf("📋 Transaction Details:\n")
-	fmt.Printf("   From: Alice (%x)\n", aliceAddr)
-	fmt.Printf("   To: Bob (%x)\n", bobAddr)
-	fmt.Printf("   Amount: %.2f coins\n", amount)
-	fmt.Printf("   Block: %d\n", block.Index)
-	fmt.Printf("   Block Hash: %x\n", block.CurrentBlockHash)
-	fmt.Printf("   Merkle Root: %x\n", block.MerkleRoot)
+	fmt.Printf("Accounts are kept in $%s (default %s)\n", wallet.WalletDirEnv, wallet.DefaultWalletDir)
 }
 
+// sendTransaction sends coins from an account of the wallet, the default one
+// unless --from names another
 func sendTransaction(args []string) {
+	from, args := fromFlag(args)
 	if len(args) < 4 {
-		fmt.Println("Usage: cli send <to_address> <amount>")
+		fmt.Println("Usage: cli send <to> <amount> [--from <account>]")
+		fmt.Println("  <to> is an address or the name of an account of the wallet")
 		return
 	}
 
-	priv, err := loadKey()
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+	key, err := unlockAccount(w, from)
 	if err != nil {
 		fmt.Printf("Error loading key: %v\n", err)
-		fmt.Println("Please run 'cli create' first to create a wallet")
+		fmt.Println("💡 Create an account with 'cli account-create <name>' first")
 		return
 	}
 
-	// Parse receiver address
-	receiverHex := args[2]
-	receiver, err := hex.DecodeString(receiverHex)
+	receiver, err := resolveRecipient(w, args[2])
 	if err != nil {
-		fmt.Printf("Invalid receiver address: %v\n", err)
+		fmt.Printf("Invalid receiver: %v\n", err)
 		return
 	}
 
@@ -356,7 +164,7 @@ func sendTransaction(args []string) {
 		return
 	}
 
-	sender := wallet.PublicKeyToAddress(&priv.PublicKey)
+	sender := key.Address()
 
 	tx := &blockchain.Transaction{
 		Sender:    sender,
@@ -365,7 +173,7 @@ func sendTransaction(args []string) {
 		Timestamp: time.Now().Unix(),
 	}
 
-	if err := wallet.SignTransaction(tx, priv); err != nil {
+	if err := wallet.SignTransactionWith(tx, key); err != nil {
 		fmt.Printf("Error signing transaction: %v\n", err)
 		return
 	}
@@ -384,12 +192,52 @@ func sendTransaction(args []string) {
 	}
 
 	fmt.Printf("✅ Transaction sent successfully!\n")
-	fmt.Printf("From: %x\n", sender)
-	fmt.Printf("To: %x\n", receiver)
+	fmt.Printf("From: %s\n", wallet.FormatAddress(sender))
+	fmt.Printf("To: %s\n", wallet.FormatAddress(receiver))
 	fmt.Printf("Amount: %.2f\n", amount)
 	fmt.Printf("Block: %d (Hash: %x)\n", block.Index, block.CurrentBlockHash)
 }
 
+// fromFlag removes a "--from <account>" (or --from=<account>) option from
+// anywhere in args, returning the account name and the remaining arguments
+func fromFlag(args []string) (string, []string) {
+	return stringFlag(args, "from")
+}
+
+// stringFlag removes a "--name <value>" (or --name=<value>) option from
+// anywhere in args, returning its value and the remaining arguments
+func stringFlag(args []string, name string) (string, []string) {
+	value := ""
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case (arg == "--"+name || arg == "-"+name) && i+1 < len(args):
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--"+name+"="), strings.HasPrefix(arg, "-"+name+"="):
+			value = arg[strings.Index(arg, "=")+1:]
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return value, rest
+}
+
+// boolFlag removes a --name (or -name) switch from args and reports whether
+// it was given
+func boolFlag(args []string, name string) (bool, []string) {
+	found := false
+	rest := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "--"+name || arg == "-"+name {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return found, rest
+}
+
 func runAliceBobDemo() {
 	fmt.Println("🚀 Running Alice & Bob Demo...")
 	// Create validator
@@ -410,7 +258,7 @@ func runAliceBobDemo() {
 	if err := saveKeyWithName(alicePriv, "alice_key.json"); err != nil {
 		log.Fatal("Failed to save Alice's key:", err)
 	}
-	fmt.Printf("Alice Address: %x\n", aliceAddr)
+	fmt.Printf("Alice Address: %s\n", wallet.FormatAddress(aliceAddr))
 
 	// Create Bob's wallet
 	fmt.Println("\n👨 Creating Bob's wallet...")
@@ -424,7 +272,7 @@ func runAliceBobDemo() {
 	if err := saveKeyWithName(bobPriv, "bob_key.json"); err != nil {
 		log.Fatal("Failed to save Bob's key:", err)
 	}
-	fmt.Printf("Bob Address: %x\n", bobAddr)
+	fmt.Printf("Bob Address: %s\n", wallet.FormatAddress(bobAddr))
 
 	// Alice sends money to Bob
 	fmt.Println("\n💰 Alice sends 50.0 coins to Bob...")
@@ -441,8 +289,8 @@ func runAliceBobDemo() {
 	}
 
 	// Verify signature
-	if !wallet.VerifyTransaction(tx1, &alicePriv.PublicKey) {
-		log.Fatal("Transaction signature invalid")
+	if err := wallet.VerifyTransaction(tx1, &alicePriv.PublicKey); err != nil {
+		log.Fatal("Transaction signature invalid:", err)
 	}
 	fmt.Println("✅ Transaction signature verified")
 
@@ -461,8 +309,8 @@ func runAliceBobDemo() {
 	}
 
 	// Verify signature
-	if !wallet.VerifyTransaction(tx2, &bobPriv.PublicKey) {
-		log.Fatal("Transaction signature invalid")
+	if err := wallet.VerifyTransaction(tx2, &bobPriv.PublicKey); err != nil {
+		log.Fatal("Transaction signature invalid:", err)
 	}
 	fmt.Println("✅ Transaction signature verified")
 
@@ -494,8 +342,8 @@ func runAliceBobDemo() {
 
 	// Test ECDSA signature verification
 	fmt.Println("📋 ECDSA Signature Verification:")
-	fmt.Printf("   ✅ Alice's signature valid: %t\n", wallet.VerifyTransaction(tx1, &alicePriv.PublicKey))
-	fmt.Printf("   ✅ Bob's signature valid: %t\n", wallet.VerifyTransaction(tx2, &bobPriv.PublicKey))
+	fmt.Printf("   ✅ Alice's signature valid: %t\n", wallet.VerifyTransaction(tx1, &alicePriv.PublicKey) == nil)
+	fmt.Printf("   ✅ Bob's signature valid: %t\n", wallet.VerifyTransaction(tx2, &bobPriv.PublicKey) == nil)
 
 	// Test Merkle Tree validation
 	fmt.Println("📋 Merkle Tree Validation:")
@@ -513,14 +361,93 @@ func runAliceBobDemo() {
 	fmt.Printf("- 2 blocks created with valid signatures and Merkle Trees\n")
 	fmt.Printf("- All data persisted in LevelDB\n")
 	fmt.Println("\n📁 Files created:")
-	fmt.Printf("- alice_key.json (Alice's ECDSA private key)\n")
-	fmt.Printf("- bob_key.json (Bob's ECDSA private key)\n")
+	fmt.Printf("- alice_key.json (Alice's encrypted ECDSA private key)\n")
+	fmt.Printf("- bob_key.json (Bob's encrypted ECDSA private key)\n")
 	fmt.Printf("- demo_blockchain/ (LevelDB blockchain database)\n")
 	fmt.Println("\n💡 Next steps:")
 	fmt.Printf("- Test 3-node consensus: docker-compose up\n")
 	fmt.Printf("- Run node recovery test: .\test-consensus.bat\n")
 }
 
+// createKey creates a key file for any supported signature scheme
+func createKey(args []string) {
+	if len(args) < 3 {
+		fmt.Println("Usage: cli create-key <file> [p256|ed25519]")
+		return
+	}
+	filename := args[2]
+	schemeName := ""
+	if len(args) > 3 {
+		schemeName = args[3]
+	}
+
+	scheme, err := wallet.ParseScheme(schemeName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if _, err := os.Stat(filename); err == nil {
+		fmt.Printf("❌ %s already exists\n", filename)
+		return
+	}
+
+	signer, err := wallet.GenerateSigner(scheme)
+	if err != nil {
+		fmt.Printf("Error generating key: %v\n", err)
+		return
+	}
+	passphrase, err := wallet.ReadPassphrase(fmt.Sprintf("New passphrase for %s: ", filename), true)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if err := wallet.SaveSigner(filename, signer, passphrase); err != nil {
+		fmt.Printf("Error saving key: %v\n", err)
+		return
+	}
+
+	fmt.Printf("✅ %s key created\n", wallet.SchemeName(scheme))
+	fmt.Printf("Address: %s\n", wallet.FormatAddress(signer.Address()))
+	fmt.Printf("💾 Keys saved to: %s\n", filename)
+}
+
+// migrateKeys encrypts plaintext key files written by earlier versions in place
+func migrateKeys(args []string) {
+	files := args[2:]
+	if len(files) == 0 {
+		files = []string{"user_key.json", "alice_key.json", "bob_key.json"}
+	}
+
+	migrated := 0
+	for _, filename := range files {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			if len(args) > 2 {
+				fmt.Printf("❌ %s: %v\n", filename, err)
+			}
+			continue
+		}
+		if !wallet.IsLegacyKeyFile(data) {
+			fmt.Printf("⏭️  %s is already encrypted\n", filename)
+			continue
+		}
+
+		passphrase, err := wallet.ReadPassphrase(fmt.Sprintf("New passphrase for %s: ", filename), true)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", filename, err)
+			continue
+		}
+		address, err := wallet.MigrateKeyFile(filename, passphrase)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", filename, err)
+			continue
+		}
+		fmt.Printf("🔒 %s encrypted (address %s)\n", filename, wallet.FormatAddress(address))
+		migrated++
+	}
+	fmt.Printf("✅ Migrated %d key file(s)\n", migrated)
+}
+
 func initBlockchain() {
 	fmt.Println("🔧 Initializing blockchain...")
 	validator, err := validator.NewValidatorNodeLegacy("./blockchain_data")
@@ -539,8 +466,7 @@ func runFullTest() {
 
 	// Test 1: ECDSA Key Generation
 	fmt.Println("📋 Test 1: ECDSA Key Generation & Digital Signatures")
-	createAlice()
-	createBob()
+	fmt.Println("✅ Alice's and Bob's keys are generated by the demo below")
 
 	// Test 2: Transaction Signing & Verification
 	fmt.Println("\n📋 Test 2: Transaction Creation & ECDSA Verification")
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+	"github.com/nguyentrinhquy1411/blockchain-go/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// defaultNode is the node verify-message asks for account keys
+const defaultNode = "localhost:50051"
+
+// signMessage proves ownership of an account by signing an off-chain message
+func signMessage(args []string) {
+	from, args := fromFlag(args)
+	if len(args) < 3 {
+		fmt.Println("Usage: cli sign-message <message|@file> [--from <account>]")
+		return
+	}
+	message, err := readMessage(args[2])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+	key, err := unlockAccount(w, from)
+	if err != nil {
+		fmt.Printf("Error loading key: %v\n", err)
+		return
+	}
+	sig, err := wallet.SignMessage(key, message)
+	if err != nil {
+		fmt.Printf("Error signing message: %v\n", err)
+		return
+	}
+
+	fmt.Printf("✍️  Signed by %s\n", sig.Address)
+	fmt.Println(sig.Encode())
+}
+
+// verifyMessage checks a signature blob produced by sign-message against the
+// key a node reports for the signing address
+func verifyMessage(args []string) {
+	node, args := stringFlag(args, "node")
+	if node == "" {
+		node = defaultNode
+	}
+	if len(args) < 4 {
+		fmt.Println("Usage: cli verify-message <message|@file> <signature> [--node <addr>]")
+		return
+	}
+	message, err := readMessage(args[2])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	sig, err := wallet.DecodeMessageSignature(args[3])
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	current, err := accountKey(node, sig.Address)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	address, err := wallet.VerifyMessage(message, sig, current)
+	if err != nil {
+		fmt.Printf("❌ Invalid signature: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Valid signature by %s (%s)\n", address, sig.Scheme)
+}
+
+// accountKey asks a node for the key that currently signs for an address, or
+// nil if the chain has not seen it yet
+func accountKey(node, address string) (*blockchain.AuthorizedKey, error) {
+	conn, err := grpc.NewClient(node, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", node, err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := proto.NewBlockchainServiceClient(conn).GetAccount(ctx, &proto.GetAccountRequest{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up %s on %s: %w", address, node, err)
+	}
+	if len(resp.PublicKey) == 0 {
+		return nil, nil
+	}
+	scheme, err := wallet.ParseScheme(resp.KeyScheme)
+	if err != nil {
+		return nil, err
+	}
+	return &blockchain.AuthorizedKey{Scheme: scheme, PublicKey: resp.PublicKey, Height: int(resp.RotatedAt)}, nil
+}
+
+// readMessage returns a message given on the command line, or the contents
+// of a file for "@path"
+func readMessage(arg string) ([]byte, error) {
+	if len(arg) > 1 && arg[0] == '@' {
+		data, err := os.ReadFile(arg[1:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message: %w", err)
+		}
+		return data, nil
+	}
+	return []byte(arg), nil
+}
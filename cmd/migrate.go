@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/storage"
+)
+
+// migrateLocal shows the pending schema migrations of a stopped node's chain
+// store and runs them, or with --dry-run only reports what they would change
+func migrateLocal(args []string) {
+	dryRun, args := boolFlag(args, "dry-run")
+	if len(args) < 3 {
+		fmt.Println("Usage: cli migrate <data-dir> [--dry-run]   (e.g. data/node1, with the node stopped)")
+		return
+	}
+	dataDir := args[2]
+	if _, err := os.Stat(dataDir); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	store, err := storage.OpenUnmigrated(dataDir)
+	if err != nil {
+		fmt.Printf("Error opening %s (is its node still running?): %v\n", dataDir, err)
+		return
+	}
+	defer store.Close()
+
+	version, err := store.SchemaVersion()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	pending, err := store.PendingMigrations()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("🗄️  %s is at schema %d of %d\n", dataDir, version, storage.LatestSchema())
+	if len(pending) == 0 {
+		fmt.Println("✅ No pending migrations")
+		return
+	}
+	for _, m := range pending {
+		fmt.Printf("  %d. %s\n", m.Version, m.Name)
+	}
+
+	if dryRun {
+		fmt.Println("🔍 Dry run, nothing is written (counts are of the store as it is now):")
+		err = store.Migrate(true, func(m storage.Migration, blocks int) {
+			fmt.Printf("  %d. would change %d block(s)\n", m.Version, blocks)
+		})
+	} else {
+		err = store.Migrate(false, func(m storage.Migration, blocks int) {
+			fmt.Printf("✅ %d. %s: %d block(s)\n", m.Version, m.Name, blocks)
+		})
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		fmt.Println("💡 Run the command again to resume from the failed migration")
+	}
+}
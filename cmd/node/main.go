@@ -10,7 +10,9 @@ import (
 
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/p2p"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/signer"
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/storage"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
 )
 
 func main() {
@@ -22,15 +24,29 @@ func main() {
 
 	log.Printf("Starting blockchain node %s...", nodeID)
 
-	// Create storage
-	storage, err := storage.NewLevelDB("data/" + nodeID)
+	if err := wallet.UseNetworkEnv(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Create storage; STORAGE_BACKEND=memory runs a throwaway node
+	backend := os.Getenv("STORAGE_BACKEND")
+	storage, err := storage.Open(backend, "data/"+nodeID)
 	if err != nil {
 		log.Fatalf("Failed to create storage: %v", err)
 	}
 	defer storage.Close()
+	if backend == "memory" {
+		log.Printf("Using in-memory storage; the chain is lost when the node stops")
+	}
 
-	// Create blockchain
-	bc, err := blockchain.NewBlockchain(storage)
+	// Create blockchain, using the genesis file when one is configured
+	genesis := blockchain.DefaultGenesis()
+	if genesisFile := os.Getenv("GENESIS_FILE"); genesisFile != "" {
+		if genesis, err = blockchain.LoadGenesis(genesisFile, wallet.DecodeAddress); err != nil {
+			log.Fatalf("Failed to load genesis: %v", err)
+		}
+	}
+	bc, err := blockchain.NewBlockchainWithGenesis(storage, genesis)
 	if err != nil {
 		log.Fatalf("Failed to create blockchain: %v", err)
 	}
@@ -45,6 +61,43 @@ func main() {
 	}
 
 	server := p2p.NewBlockchainServer(nodeID, bc, storage, peers, isLeader)
+
+	// Fee policy
+	if minFeeStr := os.Getenv("MIN_TX_FEE"); minFeeStr != "" {
+		minFee, err := strconv.ParseFloat(minFeeStr, 64)
+		if err != nil {
+			log.Fatalf("Invalid MIN_TX_FEE: %v", err)
+		}
+		server.SetMinFee(minFee)
+	}
+	if rewardAddr := os.Getenv("REWARD_ADDRESS"); rewardAddr != "" {
+		address, err := wallet.ParseAddress(rewardAddr)
+		if err != nil {
+			log.Fatalf("Invalid REWARD_ADDRESS: %v", err)
+		}
+		server.SetRewardAddress(address)
+	}
+
+	// Proposals and votes are signed by a key held in the signer daemon
+	if socket := os.Getenv("SIGNER_SOCKET"); socket != "" {
+		key, err := signer.DialKey(socket, os.Getenv("SIGNER_KEY"))
+		if err != nil {
+			log.Fatalf("Invalid SIGNER_SOCKET: %v", err)
+		}
+		server.SetSigner(key)
+		log.Printf("Signing proposals and votes with signer key %s (%s)", key.Name(), wallet.FormatAddress(key.Address()))
+	}
+	if validators := os.Getenv("VALIDATOR_ADDRESSES"); validators != "" {
+		var addresses [][]byte
+		for _, v := range strings.Split(validators, ",") {
+			address, err := wallet.ParseAddress(v)
+			if err != nil {
+				log.Fatalf("Invalid VALIDATOR_ADDRESSES: %v", err)
+			}
+			addresses = append(addresses, address)
+		}
+		server.SetValidators(addresses)
+	}
 	// Handle graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/storage"
+)
+
+// reindexLocal rebuilds the tip and the block, transaction and address
+// history indexes of a stopped node's chain store from its blocks
+func reindexLocal(args []string) {
+	if len(args) < 3 {
+		fmt.Println("Usage: cli reindex <data-dir>   (e.g. data/node1, with the node stopped)")
+		return
+	}
+	dataDir := args[2]
+	if _, err := os.Stat(dataDir); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	store, err := storage.NewLevelDB(dataDir)
+	if err != nil {
+		fmt.Printf("Error opening %s (is its node still running?): %v\n", dataDir, err)
+		return
+	}
+	defer store.Close()
+
+	fmt.Printf("🔧 Rebuilding the indexes of %s...\n", dataDir)
+	if err := store.Rebuild(); err != nil {
+		fmt.Printf("Error rebuilding indexes: %v\n", err)
+		return
+	}
+	height, err := store.LatestHeight()
+	if err != nil {
+		fmt.Printf("Error reading the tip: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Indexed %d block(s)\n", height+1)
+}
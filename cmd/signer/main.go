@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/signer"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+)
+
+func main() {
+	var (
+		configFile = flag.String("config", "signer.json", "Signer configuration (keys and policies)")
+		socketPath = flag.String("socket", "signer.sock", "Unix socket to serve the signer protocol on")
+		auditFile  = flag.String("log", "", "Append the request log to this file as well as stderr")
+		stateFile  = flag.String("state", "signer_state.json", "Record of the proposals signed, kept across restarts")
+	)
+	flag.Parse()
+
+	if err := wallet.UseNetworkEnv(); err != nil {
+		log.Fatal(err)
+	}
+
+	config, err := signer.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Every request and its outcome is logged
+	var out io.Writer = os.Stderr
+	if *auditFile != "" {
+		f, err := os.OpenFile(*auditFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			log.Fatalf("Failed to open log file: %v", err)
+		}
+		defer f.Close()
+		out = io.MultiWriter(os.Stderr, f)
+	}
+	logger := log.New(out, "signer: ", log.LstdFlags)
+
+	// Unlock the keys once at startup; clients never see them
+	var keys []*signer.Key
+	for _, kc := range config.Keys {
+		passphrase, err := wallet.ReadPassphrase(fmt.Sprintf("Passphrase for %s (%s): ", kc.Name, kc.File), false)
+		if err != nil {
+			log.Fatalf("Failed to read passphrase: %v", err)
+		}
+		key, err := wallet.LoadSigner(kc.File, passphrase)
+		if err != nil {
+			log.Fatalf("Failed to unlock %s: %v", kc.Name, err)
+		}
+		keys = append(keys, &signer.Key{Name: kc.Name, Signer: key, Policy: kc.Policy})
+		logger.Printf("loaded key %s (%s) max_amount=%g assets=%d recipients=%d consensus=%t",
+			kc.Name, wallet.FormatAddress(key.Address()), kc.MaxAmount, len(kc.AssetLimits), len(kc.AllowedRecipients), kc.Consensus)
+	}
+
+	server, err := signer.NewServer(keys, *stateFile, logger)
+	if err != nil {
+		log.Fatal(err)
+	}
+	grpcServer, listener, err := server.Listen(*socketPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Handle graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		logger.Printf("serving %d keys on %s", len(keys), *socketPath)
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("Signer stopped: %v", err)
+		}
+	}()
+
+	<-sigChan
+	logger.Printf("shutting down")
+	grpcServer.GracefulStop()
+	os.Remove(*socketPath)
+}
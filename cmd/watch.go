@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/storage"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+)
+
+// watchAddress adds a watch-only account to the wallet
+func watchAddress(args []string) {
+	if len(args) < 4 {
+		fmt.Println("Usage: cli watch-add <name> <address>")
+		return
+	}
+	address, err := wallet.ParseAddress(args[3])
+	if err != nil {
+		fmt.Printf("Invalid address: %v\n", err)
+		return
+	}
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+	account, err := w.Watch(args[2], address)
+	if err != nil {
+		fmt.Printf("Error adding watched address: %v\n", err)
+		return
+	}
+	fmt.Printf("👀 Watching %s as %s\n", account.Address, account.Name)
+	fmt.Println("💡 Run 'cli scan <data-dir>' or 'cli.exe -cmd=scan' to load its history")
+}
+
+// unwatchAddress removes a watch-only account from the wallet
+func unwatchAddress(args []string) {
+	if len(args) < 3 {
+		fmt.Println("Usage: cli watch-remove <name>")
+		return
+	}
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+	if err := w.Unwatch(args[2]); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ No longer watching %s\n", args[2])
+}
+
+// scanLocal scans the chain store of a stopped node for the wallet's accounts
+func scanLocal(args []string) {
+	if len(args) < 3 {
+		fmt.Println("Usage: cli scan <data-dir>   (e.g. data/node1, with the node stopped)")
+		return
+	}
+	dataDir := args[2]
+	if _, err := os.Stat(dataDir); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+	scanner, ok := loadScanner(w)
+	if !ok {
+		return
+	}
+
+	store, err := storage.NewLevelDB(dataDir)
+	if err != nil {
+		fmt.Printf("Error opening %s (is its node still running?): %v\n", dataDir, err)
+		return
+	}
+	defer store.Close()
+	bc, err := blockchain.NewBlockchain(store)
+	if err != nil {
+		fmt.Printf("Error loading chain: %v\n", err)
+		return
+	}
+
+	scanned, err := scanner.Scan(context.Background(), wallet.ChainBlocks(bc))
+	if err != nil {
+		fmt.Printf("Error scanning: %v\n", err)
+		return
+	}
+	fmt.Printf("🔍 Scanned %d block(s), now at height %d\n", scanned, scanner.Height())
+}
+
+// loadScanner loads the wallet's scan state, tracking all of its accounts
+func loadScanner(w *wallet.Wallet) (*wallet.Scanner, bool) {
+	accounts, err := w.Accounts()
+	if err != nil {
+		fmt.Printf("Error listing accounts: %v\n", err)
+		return nil, false
+	}
+	scanner, err := wallet.LoadScanner(w.ScanPath())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return nil, false
+	}
+
+	addresses := make([][]byte, 0, len(accounts))
+	for _, account := range accounts {
+		addresses = append(addresses, account.Address)
+	}
+	scanner.SetAddresses(addresses)
+	return scanner, true
+}
+
+// printActivity prints the scanned balances and history of the wallet's
+// accounts, or of one of them. With --memos it unlocks the accounts that
+// received encrypted memos to show them.
+func printActivity(args []string) {
+	memos, args := boolFlag(args, "memos")
+	w, ok := openWallet()
+	if !ok {
+		return
+	}
+	accounts, err := w.Accounts()
+	if err != nil {
+		fmt.Printf("Error listing accounts: %v\n", err)
+		return
+	}
+	if len(args) > 2 {
+		account, err := w.Account(args[2])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		accounts = []*wallet.Account{account}
+	}
+	scanner, err := wallet.LoadScanner(w.ScanPath())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if scanner.Height() < 0 {
+		fmt.Println("Nothing scanned yet; run 'cli scan <data-dir>' or 'cli.exe -cmd=scan' first")
+		return
+	}
+
+	fmt.Printf("Activity up to block %d:\n", scanner.Height())
+	for _, account := range accounts {
+		label := account.Name
+		if account.WatchOnly {
+			label += " (watch-only)"
+		}
+		fmt.Printf("\n%s %s\n", label, account.Address)
+
+		history := scanner.History(account.Address)
+		if history == nil {
+			fmt.Println("  not scanned yet")
+			continue
+		}
+		for _, asset := range wallet.SortedAssets(history.Balances) {
+			fmt.Printf("  Balance: %.8f %s\n", history.Balances[asset], assetName(asset))
+		}
+		if len(history.Activity) == 0 {
+			fmt.Println("  no transactions")
+		}
+		var key wallet.Signer
+		if memos && !account.WatchOnly && receivedMemo(account, history) {
+			if key, err = unlockAccount(w, account.Name); err != nil {
+				fmt.Printf("  Cannot decrypt memos: %v\n", err)
+			}
+		}
+		for _, activity := range history.Activity {
+			printActivityLine(activity)
+			printMemo(account, activity, key)
+		}
+	}
+}
+
+// receivedMemo reports whether an account received any encrypted memo
+func receivedMemo(account *wallet.Account, history *wallet.AddressHistory) bool {
+	for _, activity := range history.Activity {
+		if len(activity.Memo) > 0 && activity.To == hex.EncodeToString(account.Address) {
+			return true
+		}
+	}
+	return false
+}
+
+// printMemo shows the memo of an activity, decrypted if it was sent to the
+// account and its key is unlocked
+func printMemo(account *wallet.Account, a *wallet.Activity, key wallet.Signer) {
+	if len(a.Memo) == 0 {
+		return
+	}
+	if key == nil || a.To != hex.EncodeToString(account.Address) {
+		fmt.Println("          memo: 🔒 encrypted")
+		return
+	}
+	memo, err := wallet.DecryptMemo(key, a.Memo)
+	if err != nil {
+		fmt.Printf("          memo: 🔒 %v\n", err)
+		return
+	}
+	fmt.Printf("          memo: %q\n", memo)
+}
+
+func printActivityLine(a *wallet.Activity) {
+	txType := a.Type
+	if txType == "" {
+		txType = "transfer"
+	}
+	fmt.Printf("  #%-6d %s %-4s %-14s %.8f %s  %s -> %s  tx %s\n",
+		a.Height, time.Unix(a.Timestamp, 0).Format("2006-01-02 15:04"), a.Direction(), txType,
+		a.Amount, assetName(a.Asset), activityParty(a.From), activityParty(a.To), a.TxHash[:16])
+}
+
+// activityParty formats an address recorded in the history
+func activityParty(s string) string {
+	if s == "" || s == string(blockchain.GenesisSender) || s == string(blockchain.ConsensusSender) {
+		return s
+	}
+	address, err := wallet.ParseAddress(s)
+	if err != nil {
+		return s
+	}
+	return address.String()
+}
+
+func assetName(asset string) string {
+	if asset == blockchain.NativeAsset {
+		return blockchain.NativeAssetSymbol
+	}
+	return asset
+}
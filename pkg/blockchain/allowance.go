@@ -0,0 +1,53 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Allowance is the amount of an owner's asset a spender may still move
+type Allowance struct {
+	Owner   []byte  `json:"owner"`
+	Spender []byte  `json:"spender"`
+	Asset   string  `json:"asset"`
+	Amount  float64 `json:"amount"`
+}
+
+// TransferFrom is the payload of a TxTransferFrom transaction.
+// The spender is the sender and the recipient is the receiver.
+type TransferFrom struct {
+	Owner []byte `json:"owner"`
+}
+
+// NewApproveTransaction builds an unsigned transaction allowing spender to
+// move up to amount of the owner's asset. An amount of 0 revokes the allowance.
+func NewApproveTransaction(owner, spender []byte, asset string, amount float64) *Transaction {
+	return &Transaction{
+		Sender:    owner,
+		Receiver:  spender,
+		Amount:    amount,
+		Timestamp: time.Now().Unix(),
+		Type:      TxApprove,
+		Asset:     asset,
+	}
+}
+
+// NewTransferFromTransaction builds an unsigned transaction in which spender
+// moves amount of the owner's asset to recipient
+func NewTransferFromTransaction(spender, owner, recipient []byte, asset string, amount float64) (*Transaction, error) {
+	data, err := json.Marshal(TransferFrom{Owner: owner})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal transfer-from: %w", err)
+	}
+
+	return &Transaction{
+		Sender:    spender,
+		Receiver:  recipient,
+		Amount:    amount,
+		Timestamp: time.Now().Unix(),
+		Type:      TxTransferFrom,
+		Asset:     asset,
+		Data:      data,
+	}, nil
+}
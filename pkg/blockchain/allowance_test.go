@@ -0,0 +1,187 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+)
+
+// transferFrom has the spender move the owner's coins to the receiver
+func transferFrom(t *testing.T, spender *testAccount, owner, receiver []byte, amount float64) *Transaction {
+	t.Helper()
+	tx, err := NewTransferFromTransaction(nil, owner, receiver, NativeAsset, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spender.sign(t, tx)
+}
+
+// wantAllowance checks that the owner's index lists exactly one allowance
+// for the spender, of the given amount, or none when want is 0
+func (s *stateTest) wantAllowance(t *testing.T, owner, spender []byte, want float64) {
+	t.Helper()
+	var index []*Allowance
+	if _, err := s.state.newView(0).getJSON(allowanceIndexKey(owner), &index); err != nil {
+		t.Fatal(err)
+	}
+	allowances, err := s.state.Allowances(owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want == 0 {
+		if len(index) != 0 || len(allowances) != 0 {
+			t.Errorf("index holds %d entries and %d allowances, want none", len(index), len(allowances))
+		}
+		return
+	}
+	if len(index) != 1 || len(allowances) != 1 {
+		t.Fatalf("index holds %d entries and %d allowances, want one", len(index), len(allowances))
+	}
+	if got := allowances[0]; !bytes.Equal(got.Spender, spender) || got.Amount != want {
+		t.Errorf("allowance = %f to %x, want %f to %x", got.Amount, got.Spender, want, spender)
+	}
+}
+
+func TestApplyAllowances(t *testing.T) {
+	tests := []struct {
+		name  string
+		run   func(t *testing.T, s *stateTest) error
+		ok    bool
+		check func(t *testing.T, s *stateTest)
+	}{
+		{
+			name: "approve",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 30)))
+			},
+			ok:    true,
+			check: func(t *testing.T, s *stateTest) { s.wantAllowance(t, s.alice.address, s.bob.address, 30) },
+		},
+		{
+			name: "approve again replaces the allowance",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 30)))
+				return s.apply(2, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 20)))
+			},
+			ok:    true,
+			check: func(t *testing.T, s *stateTest) { s.wantAllowance(t, s.alice.address, s.bob.address, 20) },
+		},
+		{
+			name: "revoke",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 30)))
+				return s.apply(2, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 0)))
+			},
+			ok:    true,
+			check: func(t *testing.T, s *stateTest) { s.wantAllowance(t, s.alice.address, s.bob.address, 0) },
+		},
+		{
+			name: "approve after revoking",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 30)))
+				s.must(t, 2, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 0)))
+				return s.apply(3, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 10)))
+			},
+			ok:    true,
+			check: func(t *testing.T, s *stateTest) { s.wantAllowance(t, s.alice.address, s.bob.address, 10) },
+		},
+		{
+			name: "approve yourself",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, s.alice.sign(t, NewApproveTransaction(nil, s.alice.address, NativeAsset, 30)))
+			},
+		},
+		{
+			name: "approve without a spender",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, s.alice.sign(t, NewApproveTransaction(nil, nil, NativeAsset, 30)))
+			},
+		},
+		{
+			name: "approve an unknown asset",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, "0011223344556677", 30)))
+			},
+		},
+		{
+			name: "negative allowance",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, -1)))
+			},
+		},
+		{
+			name: "transfer from",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 30)))
+				return s.apply(2, transferFrom(t, s.bob, s.alice.address, s.bob.address, 10))
+			},
+			ok: true,
+			check: func(t *testing.T, s *stateTest) {
+				s.wantAllowance(t, s.alice.address, s.bob.address, 20)
+				s.wantBalance(t, s.alice.address, NativeAsset, 90)
+				s.wantBalance(t, s.bob.address, NativeAsset, 110)
+			},
+		},
+		{
+			name: "transfer from the whole allowance",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 30)))
+				return s.apply(2, transferFrom(t, s.bob, s.alice.address, s.bob.address, 30))
+			},
+			ok:    true,
+			check: func(t *testing.T, s *stateTest) { s.wantAllowance(t, s.alice.address, s.bob.address, 0) },
+		},
+		{
+			name: "transfer from more than the allowance",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 30)))
+				return s.apply(2, transferFrom(t, s.bob, s.alice.address, s.bob.address, 31))
+			},
+		},
+		{
+			name: "over-spend across two transfers",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 30)))
+				s.must(t, 2, transferFrom(t, s.bob, s.alice.address, s.bob.address, 20))
+				return s.apply(3, transferFrom(t, s.bob, s.alice.address, s.bob.address, 20))
+			},
+		},
+		{
+			name: "transfer from more than the owner holds",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 500)))
+				return s.apply(2, transferFrom(t, s.bob, s.alice.address, s.bob.address, 200))
+			},
+		},
+		{
+			name: "transfer from after revocation",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 30)))
+				s.must(t, 2, s.alice.sign(t, NewApproveTransaction(nil, s.bob.address, NativeAsset, 0)))
+				return s.apply(3, transferFrom(t, s.bob, s.alice.address, s.bob.address, 1))
+			},
+		},
+		{
+			name: "transfer from without an allowance",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, transferFrom(t, s.bob, s.alice.address, s.bob.address, 1))
+			},
+		},
+		{
+			name: "transfer from without an owner",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, transferFrom(t, s.bob, nil, s.bob.address, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStateTest(t)
+			if err := tt.run(t, s); (err == nil) != tt.ok {
+				t.Fatalf("apply = %v, want ok=%t", err, tt.ok)
+			}
+			if tt.check != nil {
+				tt.check(t, s)
+			}
+		})
+	}
+}
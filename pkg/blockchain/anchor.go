@@ -0,0 +1,153 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxAnchorPayload is the largest document that can be embedded on-chain
+	MaxAnchorPayload = 4096
+
+	maxContentTypeLength = 128
+)
+
+// AnchorData is the payload of a TxAnchor transaction. Either the document
+// itself is embedded (Hash must then be its SHA-256) or only its hash is
+// committed.
+type AnchorData struct {
+	Hash        []byte `json:"hash"`
+	Payload     []byte `json:"payload,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// AnchorRecord locates the transaction that first anchored a hash
+type AnchorRecord struct {
+	Hash    []byte `json:"hash"`
+	Height  int    `json:"height"`
+	TxIndex int    `json:"tx_index"`
+}
+
+// AnchorReceipt is a verifiable notarization receipt: the anchoring
+// transaction, the block that holds it and a Merkle inclusion proof
+type AnchorReceipt struct {
+	Hash        []byte
+	ContentType string
+	Height      int
+	Timestamp   int64
+	BlockHash   []byte
+	MerkleRoot  []byte
+	TxIndex     int
+	Transaction *Transaction
+	Proof       []MerkleProofStep
+}
+
+// Verify checks the receipt against a block the caller trusts, fetched by
+// the receipt's block hash: the block must hash to BlockHash over its own
+// Merkle root, and the transaction must anchor the receipt's hash and be
+// included under that root. A receipt alone proves nothing, since its root
+// could be made up to match any proof.
+func (r *AnchorReceipt) Verify(block *Block) error {
+	if block == nil {
+		return fmt.Errorf("missing block")
+	}
+	if !bytes.Equal(block.CurrentBlockHash, r.BlockHash) || block.Index != r.Height {
+		return fmt.Errorf("receipt is for block %d (%x), not block %d (%x)",
+			r.Height, r.BlockHash, block.Index, block.CurrentBlockHash)
+	}
+	if !block.IsValid() {
+		return fmt.Errorf("block %x does not hash to its contents", block.CurrentBlockHash)
+	}
+	if !bytes.Equal(block.MerkleRoot, r.MerkleRoot) {
+		return fmt.Errorf("receipt root %x is not the block's root %x", r.MerkleRoot, block.MerkleRoot)
+	}
+
+	data, err := r.Transaction.anchorData()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(data.Hash, r.Hash) {
+		return fmt.Errorf("transaction anchors %x, not %x", data.Hash, r.Hash)
+	}
+
+	txHash, err := r.Transaction.Hash()
+	if err != nil {
+		return err
+	}
+	if !VerifyMerkleProof(txHash, r.Proof, block.MerkleRoot) {
+		return fmt.Errorf("merkle proof does not match root %x", block.MerkleRoot)
+	}
+	return nil
+}
+
+// NewAnchorTransaction builds an unsigned anchoring transaction for a
+// document. When embed is false only the document hash goes on-chain.
+func NewAnchorTransaction(sender, document []byte, contentType string, embed bool) (*Transaction, error) {
+	hash := sha256.Sum256(document)
+	data := AnchorData{Hash: hash[:], ContentType: contentType}
+	if embed {
+		data.Payload = document
+	}
+	return newAnchorTransaction(sender, data)
+}
+
+// NewHashAnchorTransaction builds an unsigned transaction committing to a
+// precomputed SHA-256 hash
+func NewHashAnchorTransaction(sender, hash []byte, contentType string) (*Transaction, error) {
+	return newAnchorTransaction(sender, AnchorData{Hash: hash, ContentType: contentType})
+}
+
+func newAnchorTransaction(sender []byte, data AnchorData) (*Transaction, error) {
+	if err := data.validate(); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal anchor: %w", err)
+	}
+
+	return &Transaction{
+		Sender:    sender,
+		Timestamp: time.Now().Unix(),
+		Type:      TxAnchor,
+		Data:      payload,
+	}, nil
+}
+
+func (t *Transaction) anchorData() (*AnchorData, error) {
+	if t.Type != TxAnchor {
+		return nil, fmt.Errorf("transaction is not an anchor")
+	}
+
+	var data AnchorData
+	if err := json.Unmarshal(t.Data, &data); err != nil {
+		return nil, fmt.Errorf("invalid anchor payload: %w", err)
+	}
+	if err := data.validate(); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (d AnchorData) validate() error {
+	if len(d.Hash) != sha256.Size {
+		return fmt.Errorf("anchor hash must be %d bytes", sha256.Size)
+	}
+	if len(d.Payload) > MaxAnchorPayload {
+		return fmt.Errorf("anchor payload exceeds %d bytes", MaxAnchorPayload)
+	}
+	if d.Payload != nil {
+		sum := sha256.Sum256(d.Payload)
+		if !bytes.Equal(sum[:], d.Hash) {
+			return fmt.Errorf("anchor hash does not match payload")
+		}
+	}
+	if len(d.ContentType) > maxContentTypeLength {
+		return fmt.Errorf("content type exceeds %d characters", maxContentTypeLength)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package blockchain
+
+import "testing"
+
+// merkleProof proves the transaction at index is in block
+func merkleProof(t *testing.T, block *Block, index int) []MerkleProofStep {
+	t.Helper()
+	var txHashes [][]byte
+	for _, tx := range block.Transactions {
+		hash, err := tx.Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		txHashes = append(txHashes, hash)
+	}
+	proof, err := NewMerkleTree(txHashes).Proof(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return proof
+}
+
+// anchorReceipt builds the receipt for the anchor at index in block
+func anchorReceipt(t *testing.T, block *Block, index int) *AnchorReceipt {
+	t.Helper()
+	data, err := block.Transactions[index].anchorData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &AnchorReceipt{
+		Hash:        data.Hash,
+		Height:      block.Index,
+		Timestamp:   block.Timestamp,
+		BlockHash:   block.CurrentBlockHash,
+		MerkleRoot:  block.MerkleRoot,
+		TxIndex:     index,
+		Transaction: block.Transactions[index],
+		Proof:       merkleProof(t, block, index),
+	}
+}
+
+func TestAnchorReceiptVerify(t *testing.T) {
+	account := newTestAccount(t)
+	anchor, err := NewAnchorTransaction(nil, []byte("document"), "text/plain", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs := []*Transaction{
+		account.sign(t, &Transaction{Receiver: make([]byte, AddressLength), Amount: 1}),
+		account.sign(t, anchor),
+		account.sign(t, &Transaction{Receiver: make([]byte, AddressLength), Amount: 2}),
+	}
+	block := NewBlock(4, txs, make([]byte, 32))
+	other := NewBlock(5, txs[:1], block.CurrentBlockHash)
+
+	tests := []struct {
+		name  string
+		forge func(r *AnchorReceipt) *Block // Tampers with the receipt and returns the block to check against
+		ok    bool
+	}{
+		{"valid", func(r *AnchorReceipt) *Block { return block }, true},
+		{"no block", func(r *AnchorReceipt) *Block { return nil }, false},
+		{"other block", func(r *AnchorReceipt) *Block { return other }, false},
+		{
+			// A root of the anchor alone matches an empty proof, but it is
+			// not the root the block hash commits to
+			"made-up root",
+			func(r *AnchorReceipt) *Block {
+				hash, _ := r.Transaction.Hash()
+				r.MerkleRoot = NewMerkleTree([][]byte{hash}).GetRoot()
+				r.Proof = nil
+				return block
+			},
+			false,
+		},
+		{
+			"block that does not hash to its contents",
+			func(r *AnchorReceipt) *Block {
+				forged := *block
+				forged.Transactions = txs[1:2]
+				forged.CalculateMerkleRoot()
+				r.MerkleRoot = forged.MerkleRoot
+				r.Proof = nil
+				return &forged
+			},
+			false,
+		},
+		{
+			"proof for another transaction",
+			func(r *AnchorReceipt) *Block {
+				r.Proof = merkleProof(t, block, 0)
+				return block
+			},
+			false,
+		},
+		{
+			"other hash",
+			func(r *AnchorReceipt) *Block {
+				r.Hash = make([]byte, 32)
+				return block
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := anchorReceipt(t, block, 1)
+			trusted := tt.forge(receipt)
+			if err := receipt.Verify(trusted); (err == nil) != tt.ok {
+				t.Errorf("Verify() = %v, want ok=%t", err, tt.ok)
+			}
+		})
+	}
+}
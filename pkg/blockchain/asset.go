@@ -0,0 +1,82 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// NativeAsset is the asset ID of the chain's built-in coin
+const (
+	NativeAsset       = ""
+	NativeAssetSymbol = "COIN"
+
+	maxAssetSymbolLength = 12
+	maxAssetDecimals     = 18
+)
+
+// Asset describes a fungible token issued on the chain
+type Asset struct {
+	ID       string  `json:"id"`
+	Symbol   string  `json:"symbol"`
+	Decimals int     `json:"decimals"`
+	Supply   float64 `json:"supply"`
+	Issuer   []byte  `json:"issuer"`
+	Height   int     `json:"height"` // Block height the asset was issued at
+}
+
+// AssetIssue is the payload of a TxIssueAsset transaction.
+// The issuer is the transaction sender and the supply is its Amount.
+type AssetIssue struct {
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+}
+
+// NewIssueAssetTransaction builds an unsigned transaction issuing a new asset
+func NewIssueAssetTransaction(issuer []byte, symbol string, decimals int, supply float64) (*Transaction, error) {
+	issue := AssetIssue{Symbol: symbol, Decimals: decimals}
+	if err := issue.validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal asset issue: %w", err)
+	}
+
+	return &Transaction{
+		Sender:    issuer,
+		Amount:    supply,
+		Timestamp: time.Now().Unix(),
+		Type:      TxIssueAsset,
+		Data:      data,
+	}, nil
+}
+
+// AssetID derives the ID of the asset created by an issue transaction
+func AssetID(tx *Transaction) (string, error) {
+	hash, err := tx.Hash()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash[:8]), nil
+}
+
+func (ai AssetIssue) validate() error {
+	if len(ai.Symbol) == 0 || len(ai.Symbol) > maxAssetSymbolLength {
+		return fmt.Errorf("asset symbol must be 1-%d characters", maxAssetSymbolLength)
+	}
+	for _, c := range ai.Symbol {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return fmt.Errorf("asset symbol %q must contain only A-Z and 0-9", ai.Symbol)
+		}
+	}
+	if ai.Symbol == NativeAssetSymbol {
+		return fmt.Errorf("asset symbol %s is reserved", NativeAssetSymbol)
+	}
+	if ai.Decimals < 0 || ai.Decimals > maxAssetDecimals {
+		return fmt.Errorf("asset decimals must be between 0 and %d", maxAssetDecimals)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package blockchain
+
+import "testing"
+
+// issueAsset has the account issue an asset and returns the transaction
+func issueAsset(t *testing.T, account *testAccount, symbol string, supply float64) *Transaction {
+	t.Helper()
+	tx, err := NewIssueAssetTransaction(nil, symbol, 2, supply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account.sign(t, tx)
+}
+
+// assetID returns the ID of the asset created by an issue transaction
+func assetID(t *testing.T, issue *Transaction) string {
+	t.Helper()
+	id, err := AssetID(issue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestApplyAssets(t *testing.T) {
+	tests := []struct {
+		name  string
+		run   func(t *testing.T, s *stateTest) error
+		ok    bool
+		check func(t *testing.T, s *stateTest)
+	}{
+		{
+			name: "issue",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, issueAsset(t, s.alice, "GOLD", 500))
+			},
+			ok: true,
+			check: func(t *testing.T, s *stateTest) {
+				assets, err := s.state.Assets()
+				if err != nil || len(assets) != 1 {
+					t.Fatalf("Assets() = %v, %v, want one asset", assets, err)
+				}
+				if asset := assets[0]; asset.Symbol != "GOLD" || asset.Supply != 500 || asset.Height != 1 {
+					t.Errorf("asset = %+v, want GOLD with supply 500 at height 1", asset)
+				}
+				s.wantBalance(t, s.alice.address, assets[0].ID, 500)
+			},
+		},
+		{
+			name: "issue pays its fee",
+			run: func(t *testing.T, s *stateTest) error {
+				issue, err := NewIssueAssetTransaction(nil, "GOLD", 2, 500)
+				if err != nil {
+					t.Fatal(err)
+				}
+				issue.Fee = 5
+				return s.apply(1, s.alice.sign(t, issue))
+			},
+			ok: true,
+			check: func(t *testing.T, s *stateTest) {
+				s.wantBalance(t, s.alice.address, NativeAsset, 95)
+			},
+		},
+		{
+			name: "symbol already in use",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, issueAsset(t, s.alice, "GOLD", 500))
+				return s.apply(2, issueAsset(t, s.bob, "GOLD", 10))
+			},
+		},
+		{
+			name: "reserved symbol",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, s.alice.sign(t, &Transaction{
+					Type:   TxIssueAsset,
+					Amount: 500,
+					Data:   []byte(`{"symbol":"COIN","decimals":2}`),
+				}))
+			},
+		},
+		{
+			name: "malformed payload",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, s.alice.sign(t, &Transaction{Type: TxIssueAsset, Amount: 500, Data: []byte("{")}))
+			},
+		},
+		{
+			name: "issue naming an asset",
+			run: func(t *testing.T, s *stateTest) error {
+				issue, err := NewIssueAssetTransaction(nil, "GOLD", 2, 500)
+				if err != nil {
+					t.Fatal(err)
+				}
+				issue.Asset = "0011223344556677"
+				return s.apply(1, s.alice.sign(t, issue))
+			},
+		},
+		{
+			name: "zero supply",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, issueAsset(t, s.alice, "GOLD", 0))
+			},
+		},
+		{
+			name: "transfer",
+			run: func(t *testing.T, s *stateTest) error {
+				issue := issueAsset(t, s.alice, "GOLD", 500)
+				s.must(t, 1, issue)
+				return s.apply(2, s.alice.sign(t, &Transaction{Receiver: s.bob.address, Amount: 200, Asset: assetID(t, issue)}))
+			},
+			ok: true,
+			check: func(t *testing.T, s *stateTest) {
+				assets, err := s.state.Assets()
+				if err != nil || len(assets) != 1 {
+					t.Fatalf("Assets() = %v, %v, want one asset", assets, err)
+				}
+				s.wantBalance(t, s.alice.address, assets[0].ID, 300)
+				s.wantBalance(t, s.bob.address, assets[0].ID, 200)
+				s.wantBalance(t, s.alice.address, NativeAsset, 100)
+			},
+		},
+		{
+			name: "transfer more than the balance",
+			run: func(t *testing.T, s *stateTest) error {
+				issue := issueAsset(t, s.alice, "GOLD", 500)
+				s.must(t, 1, issue)
+				return s.apply(2, s.alice.sign(t, &Transaction{Receiver: s.bob.address, Amount: 501, Asset: assetID(t, issue)}))
+			},
+		},
+		{
+			name: "transfer without holding the asset",
+			run: func(t *testing.T, s *stateTest) error {
+				issue := issueAsset(t, s.alice, "GOLD", 500)
+				s.must(t, 1, issue)
+				return s.apply(2, s.bob.sign(t, &Transaction{Receiver: s.alice.address, Amount: 1, Asset: assetID(t, issue)}))
+			},
+		},
+		{
+			name: "transfer of an unknown asset",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, s.alice.sign(t, &Transaction{Receiver: s.bob.address, Amount: 1, Asset: "0011223344556677"}))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStateTest(t)
+			if err := tt.run(t, s); (err == nil) != tt.ok {
+				t.Fatalf("apply = %v, want ok=%t", err, tt.ok)
+			}
+			if tt.check != nil {
+				tt.check(t, s)
+			}
+		})
+	}
+}
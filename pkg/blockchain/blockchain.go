@@ -1,66 +1,150 @@
 package blockchain
 
 import (
-	"encoding/json"
+	"bytes"
+	"encoding/hex"
 	"fmt"
+	"strconv"
+	"sync"
 )
 
-// Storage interface để tránh import cycle
+// Storage is the chain store (storage.Store), declared here to avoid an
+// import cycle. State keys are read and written directly; blocks go through
+// WriteBlock together with their state changes.
 type Storage interface {
 	Get(key string) ([]byte, error)
 	Put(key string, value []byte) error
+
+	// WriteBlock atomically stores a block, its indexes, the state changes
+	// it made and the new tip
+	WriteBlock(block *Block, changes []StateChange) error
+	BlockByHeight(height int) (*Block, error)
+	BlockHeight(hash []byte) (int, error)
+	LatestHeight() (int, error) // -1 for an empty store
+	TxLocation(hash []byte) (*TxLocation, error)
+	AddressHistory(address []byte, query HistoryQuery) (*HistoryPage, error)
+}
+
+// TxLocation is where a committed transaction sits in the chain
+type TxLocation struct {
+	Height   int `json:"height"`
+	Position int `json:"position"` // Index in the block's transactions
 }
 
 type Blockchain struct {
 	storage Storage
 	genesis *Block
+	latest  *Block
+	state   *State
+	mutex   sync.RWMutex
 }
 
 func NewBlockchain(storage Storage) (*Blockchain, error) {
+	return NewBlockchainWithGenesis(storage, DefaultGenesis())
+}
+
+// NewBlockchainWithGenesis opens the chain in storage, creating its genesis
+// block from the given configuration if the storage is empty
+func NewBlockchainWithGenesis(storage Storage, genesis *Genesis) (*Blockchain, error) {
 	bc := &Blockchain{
 		storage: storage,
+		state:   NewState(storage),
 	}
 
 	// Try to load existing blockchain or create genesis
-	if err := bc.loadOrCreateGenesis(); err != nil {
+	if err := bc.loadOrCreateGenesis(genesis); err != nil {
 		return nil, err
 	}
 
 	return bc, nil
 }
 
-func (bc *Blockchain) loadOrCreateGenesis() error {
+func (bc *Blockchain) loadOrCreateGenesis(genesis *Genesis) error {
 	// Try to load genesis block
-	genesisData, err := bc.storage.Get("genesis")
+	stored, err := bc.storage.BlockByHeight(0)
 	if err != nil {
 		// Create genesis block
-		genesisTransactions := []*Transaction{
-			{
-				Sender:    []byte("genesis"),
-				Receiver:  []byte("alice"),
-				Amount:    100.0,
-				Timestamp: 0,
-			},
+		block, err := genesis.Block()
+		if err != nil {
+			return fmt.Errorf("invalid genesis configuration: %w", err)
 		}
 
-		bc.genesis = NewBlock(0, genesisTransactions, []byte(""))
+		bc.genesis = block
+		bc.latest = bc.genesis
 
-		// Save genesis block
-		return bc.saveBlock(bc.genesis, "genesis")
+		// Apply genesis allocations to the state
+		view := bc.state.newView(0)
+		view.put(chainIDKey, []byte(genesis.ChainID))
+		if err := view.putJSON(replayHeightKey, genesis.ReplayHeight); err != nil {
+			return err
+		}
+		if err := view.applyBlock(bc.genesis); err != nil {
+			return fmt.Errorf("failed to apply genesis block: %w", err)
+		}
+
+		// Save genesis block with its state
+		return bc.storage.WriteBlock(bc.genesis, view.changes())
+	}
+	bc.genesis = stored
+
+	// Chains created before chain IDs existed adopt the configured one
+	if bc.state.ChainID() == "" {
+		if err := bc.storage.Put(chainIDKey, []byte(genesis.ChainID)); err != nil {
+			return fmt.Errorf("failed to record chain ID: %w", err)
+		}
 	}
+	if err := bc.adoptReplayHeight(genesis); err != nil {
+		return err
+	}
+
+	return bc.loadLatest()
+}
 
-	// Load existing genesis
-	if err := json.Unmarshal(genesisData, &bc.genesis); err != nil {
-		return fmt.Errorf("failed to unmarshal genesis block: %w", err)
+// adoptReplayHeight records the replay height of a chain created before
+// replay protection: the configured one or, failing that, the block after
+// the current tip, since the blocks already stored may hold transactions
+// without a nonce. Nodes of one network must agree on it, so clusters
+// upgrading an existing chain should configure it.
+func (bc *Blockchain) adoptReplayHeight(genesis *Genesis) error {
+	if _, err := bc.storage.Get(replayHeightKey); err == nil {
+		return nil
 	}
+	height := genesis.ReplayHeight
+	if height == 0 {
+		latest, err := bc.storage.LatestHeight()
+		if err != nil {
+			return err
+		}
+		height = latest + 1
+	}
+	if err := bc.storage.Put(replayHeightKey, []byte(strconv.Itoa(height))); err != nil {
+		return fmt.Errorf("failed to record replay height: %w", err)
+	}
+	return nil
+}
 
+// loadLatest loads the block named by the store's tip record
+func (bc *Blockchain) loadLatest() error {
+	height, err := bc.storage.LatestHeight()
+	if err != nil {
+		return err
+	}
+	if height <= 0 {
+		bc.latest = bc.genesis
+		return nil
+	}
+	latest, err := bc.loadBlock(height)
+	if err != nil {
+		return fmt.Errorf("failed to load chain tip: %w", err)
+	}
+	bc.latest = latest
 	return nil
 }
 
 func (bc *Blockchain) GetLatestBlock() *Block {
-	// For now, return genesis. In a full implementation,
-	// you'd track the latest block height
-	return bc.genesis
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	return bc.latest
 }
 
 func (bc *Blockchain) GetBlockByHeight(height int) (*Block, error) {
@@ -68,17 +152,47 @@ func (bc *Blockchain) GetBlockByHeight(height int) (*Block, error) {
 		return bc.genesis, nil
 	}
 
-	// For now, only genesis is implemented
-	return nil, fmt.Errorf("block at height %d not found", height)
+	return bc.loadBlock(height)
 }
 
+// GetTransaction returns a committed transaction with the block that holds
+// it and its position there
+func (bc *Blockchain) GetTransaction(hash []byte) (*Transaction, *Block, int, error) {
+	location, err := bc.storage.TxLocation(hash)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("transaction %x not found", hash)
+	}
+	block, err := bc.GetBlockByHeight(location.Height)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if location.Position >= len(block.Transactions) {
+		return nil, nil, 0, fmt.Errorf("transaction index of %x points past block %d", hash, location.Height)
+	}
+	return block.Transactions[location.Position], block, location.Position, nil
+}
+
+// GetAddressHistory returns a page of the committed transactions that an
+// address sent or received
+func (bc *Blockchain) GetAddressHistory(address []byte, query HistoryQuery) (*HistoryPage, error) {
+	return bc.storage.AddressHistory(address, query)
+}
+
+// GetBlockByHash returns the block with the given hex hash
 func (bc *Blockchain) GetBlockByHash(hash string) (*Block, error) {
-	// Simple implementation - check if it's genesis
-	if string(bc.genesis.CurrentBlockHash) == hash {
+	raw, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block hash %q: %w", hash, err)
+	}
+	if bytes.Equal(bc.genesis.CurrentBlockHash, raw) {
 		return bc.genesis, nil
 	}
 
-	return nil, fmt.Errorf("block with hash %s not found", hash)
+	height, err := bc.storage.BlockHeight(raw)
+	if err != nil {
+		return nil, fmt.Errorf("block with hash %s not found", hash)
+	}
+	return bc.loadBlock(height)
 }
 
 func (bc *Blockchain) AddBlock(block *Block) error {
@@ -87,9 +201,214 @@ func (bc *Blockchain) AddBlock(block *Block) error {
 		return fmt.Errorf("invalid block")
 	}
 
-	// Save block
-	key := fmt.Sprintf("block_%d", block.Index)
-	return bc.saveBlock(block, key)
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	// Block must extend the current tip
+	if err := bc.checkLinks(block); err != nil {
+		return err
+	}
+
+	// Apply transactions to the state
+	view := bc.state.newView(block.Index)
+	if err := view.applyBlock(block); err != nil {
+		return fmt.Errorf("invalid state transition: %w", err)
+	}
+
+	// Save the block, its indexes and its state changes at once
+	if err := bc.storage.WriteBlock(block, view.changes()); err != nil {
+		return err
+	}
+
+	bc.latest = block
+	return nil
+}
+
+// CheckBlock verifies that a block extends the tip and that its transactions
+// apply cleanly to the current state, without committing anything
+func (bc *Blockchain) CheckBlock(block *Block) error {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+
+	if err := bc.checkLinks(block); err != nil {
+		return err
+	}
+	return bc.state.newView(block.Index).applyBlock(block)
+}
+
+// CheckTransaction verifies that a transaction would apply cleanly on top of
+// the current state. A nonce past the sender's next one is accepted, since
+// the transactions before it may still be pending.
+func (bc *Blockchain) CheckTransaction(tx *Transaction) error {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+
+	view := bc.state.newView(bc.latest.Index + 1)
+	view.futureNonces = true
+	view.newTransactions = true
+	return view.applyTransaction(tx)
+}
+
+// SelectTransactions returns, in order, up to limit candidate transactions
+// that apply cleanly on top of the current state and of each other.
+// Candidates that would fail are skipped.
+func (bc *Blockchain) SelectTransactions(candidates []*Transaction, limit int) []*Transaction {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+
+	view := bc.state.newView(bc.latest.Index + 1)
+	view.newTransactions = true
+	var selected []*Transaction
+	for _, tx := range candidates {
+		if len(selected) >= limit {
+			break
+		}
+
+		// Apply on a child view so a failing transaction leaves no trace
+		child := view.child()
+		if err := child.applyTransaction(tx); err != nil {
+			continue
+		}
+		child.merge()
+		selected = append(selected, tx)
+	}
+	return selected
+}
+
+// GetBalance returns the balance of an address for an asset
+func (bc *Blockchain) GetBalance(address []byte, asset string) (float64, error) {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	return bc.state.Balance(address, asset)
+}
+
+// GetAsset returns an issued asset by ID, or nil if it does not exist
+func (bc *Blockchain) GetAsset(id string) (*Asset, error) {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	return bc.state.Asset(id)
+}
+
+// GetAssets returns every issued asset
+func (bc *Blockchain) GetAssets() ([]*Asset, error) {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	return bc.state.Assets()
+}
+
+// ResolveName returns the current owner record of a name, or nil if the
+// name is unregistered or has expired
+func (bc *Blockchain) ResolveName(name string) (*NameRecord, error) {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+
+	record, err := bc.state.Name(name)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	if record.Expired(bc.latest.Index) {
+		return nil, nil
+	}
+	return record, nil
+}
+
+// GetAnchor builds a notarization receipt for an anchored hash, or returns
+// nil if the hash has not been anchored
+func (bc *Blockchain) GetAnchor(hash []byte) (*AnchorReceipt, error) {
+	bc.mutex.RLock()
+	record, err := bc.state.Anchor(hash)
+	bc.mutex.RUnlock()
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	block, err := bc.GetBlockByHeight(record.Height)
+	if err != nil {
+		return nil, err
+	}
+	if record.TxIndex >= len(block.Transactions) {
+		return nil, fmt.Errorf("anchor index %d out of range in block %d", record.TxIndex, block.Index)
+	}
+
+	var txHashes [][]byte
+	for _, tx := range block.Transactions {
+		txHash, err := tx.Hash()
+		if err != nil {
+			return nil, err
+		}
+		txHashes = append(txHashes, txHash)
+	}
+	proof, err := NewMerkleTree(txHashes).Proof(record.TxIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := block.Transactions[record.TxIndex]
+	data, err := tx.anchorData()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnchorReceipt{
+		Hash:        record.Hash,
+		ContentType: data.ContentType,
+		Height:      block.Index,
+		Timestamp:   block.Timestamp,
+		BlockHash:   block.CurrentBlockHash,
+		MerkleRoot:  block.MerkleRoot,
+		TxIndex:     record.TxIndex,
+		Transaction: tx,
+		Proof:       proof,
+	}, nil
+}
+
+// GetAllowances returns the outstanding allowances granted by an owner
+func (bc *Blockchain) GetAllowances(owner []byte) ([]*Allowance, error) {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	return bc.state.Allowances(owner)
+}
+
+// ChainID returns the identifier transactions name to be valid on this chain
+func (bc *Blockchain) ChainID() string {
+	return bc.state.ChainID()
+}
+
+// GetNonce returns the number of transactions an address has sent. Its next
+// transaction must carry this value plus one (or no nonce).
+func (bc *Blockchain) GetNonce(address []byte) (uint64, error) {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	return bc.state.Nonce(address)
+}
+
+// GetAuthorizedKey returns the key an address was rotated to, or nil if it
+// was never rotated
+func (bc *Blockchain) GetAuthorizedKey(address []byte) (*AuthorizedKey, error) {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	return bc.state.AuthorizedKey(address)
+}
+
+// GetPublicKey returns the key that currently signs for an address, or nil
+// if it is not known yet
+func (bc *Blockchain) GetPublicKey(address []byte) (*AuthorizedKey, error) {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	return bc.state.PublicKey(address)
+}
+
+// GetVesting returns the vested and locked native balance of an address at
+// the current height
+func (bc *Blockchain) GetVesting(address []byte) (*VestingStatus, error) {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	return bc.state.Vesting(address, bc.latest.Index)
 }
 
 func (bc *Blockchain) CalculateMerkleRoot(transactions []*Transaction) string {
@@ -112,11 +431,16 @@ func (bc *Blockchain) CalculateMerkleRoot(transactions []*Transaction) string {
 	return string(merkleTree.GetRoot())
 }
 
-func (bc *Blockchain) saveBlock(block *Block, key string) error {
-	blockData, err := json.Marshal(block)
-	if err != nil {
-		return fmt.Errorf("failed to marshal block: %w", err)
+func (bc *Blockchain) checkLinks(block *Block) error {
+	if block.Index != bc.latest.Index+1 {
+		return fmt.Errorf("block %d does not extend chain tip %d", block.Index, bc.latest.Index)
 	}
+	if !bytes.Equal(block.PreviousBlockHash, bc.latest.CurrentBlockHash) {
+		return fmt.Errorf("block %d previous hash does not match chain tip", block.Index)
+	}
+	return nil
+}
 
-	return bc.storage.Put(key, blockData)
+func (bc *Blockchain) loadBlock(height int) (*Block, error) {
+	return bc.storage.BlockByHeight(height)
 }
@@ -0,0 +1,83 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Direction filters an address history by the address' side of each transaction
+type Direction string
+
+const (
+	DirectionAll      Direction = ""
+	DirectionSent     Direction = "sent"
+	DirectionReceived Direction = "received"
+)
+
+// ParseDirection checks a direction filter given by a client
+func ParseDirection(s string) (Direction, error) {
+	switch direction := Direction(s); direction {
+	case DirectionAll, DirectionSent, DirectionReceived:
+		return direction, nil
+	case "all":
+		return DirectionAll, nil
+	default:
+		return "", fmt.Errorf("unknown direction %q (use sent, received or all)", s)
+	}
+}
+
+// AddressTx is one transaction in the history of an address
+type AddressTx struct {
+	Hash     []byte `json:"hash"`
+	Height   int    `json:"height"`
+	Position int    `json:"position"`
+	Sent     bool   `json:"sent,omitempty"`     // The address paid the amount or fee
+	Received bool   `json:"received,omitempty"` // The address was the receiver
+}
+
+// Matches reports whether the entry passes a direction filter
+func (a *AddressTx) Matches(direction Direction) bool {
+	switch direction {
+	case DirectionSent:
+		return a.Sent
+	case DirectionReceived:
+		return a.Received
+	default:
+		return true
+	}
+}
+
+// HistoryQuery selects one page of an address history. Pages run from the
+// newest transaction back unless OldestFirst is set.
+type HistoryQuery struct {
+	Direction   Direction
+	Cursor      string // Next of the previous page, empty for the first page
+	Limit       int
+	OldestFirst bool
+}
+
+// HistoryPage is one page of an address history
+type HistoryPage struct {
+	Entries []AddressTx
+	Next    string // Cursor of the following page, empty after the last one
+}
+
+// Parties returns the addresses that sent and received a transaction.
+// Senders are the signer and, for transfer-from, the owner whose funds moved;
+// the system senders of minted coins are left out.
+func (t *Transaction) Parties() (sent, received [][]byte) {
+	if !t.IsCoinbase() && len(t.Sender) > 0 {
+		sent = append(sent, t.Sender)
+	}
+	if t.Type == TxTransferFrom {
+		var op TransferFrom
+		if err := json.Unmarshal(t.Data, &op); err == nil && len(op.Owner) > 0 && !bytes.Equal(op.Owner, t.Sender) {
+			sent = append(sent, op.Owner)
+		}
+	}
+	if len(t.Receiver) > 0 {
+		received = append(received, t.Receiver)
+	}
+	return sent, received
+}
@@ -0,0 +1,32 @@
+package blockchain
+
+import "fmt"
+
+// Encrypted memos are sealed by the sender's wallet for the receiver's P-256
+// key (see wallet.EncryptMemo):
+//
+//	version (1) || ephemeral public key (65) || nonce (12) || ciphertext || tag (16)
+//
+// Nodes cannot read them; they only check the version and size.
+const (
+	MemoVersion   byte = 1
+	MaxMemoLength      = 256 // Longest plaintext memo in bytes
+	MemoOverhead       = 1 + 65 + 12 + 16
+)
+
+// checkMemo checks the shape of a transaction's encrypted memo
+func (t *Transaction) checkMemo() error {
+	if len(t.Memo) == 0 {
+		return nil
+	}
+	if t.IsCoinbase() || len(t.Receiver) == 0 {
+		return fmt.Errorf("only transactions with a receiver can carry a memo")
+	}
+	if t.Memo[0] != MemoVersion {
+		return fmt.Errorf("unsupported memo version %d", t.Memo[0])
+	}
+	if len(t.Memo) < MemoOverhead || len(t.Memo) > MemoOverhead+MaxMemoLength {
+		return fmt.Errorf("invalid encrypted memo size %d", len(t.Memo))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package blockchain
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MaxPoolSize is the maximum number of pending transactions kept in memory
+const MaxPoolSize = 10000
+
+// TxPool holds transactions waiting to be included in a block
+type TxPool struct {
+	mutex sync.Mutex
+	txs   map[string]*Transaction // Maps transaction hash to transaction
+	order []string                // Transaction hashes in arrival order
+}
+
+// NewTxPool creates an empty transaction pool
+func NewTxPool() *TxPool {
+	return &TxPool{
+		txs: make(map[string]*Transaction),
+	}
+}
+
+// Add queues a transaction for inclusion in a future block
+func (p *TxPool) Add(tx *Transaction) error {
+	hash, err := tx.Hash()
+	if err != nil {
+		return err
+	}
+	key := string(hash)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, exists := p.txs[key]; exists {
+		return fmt.Errorf("transaction %x already pending", hash)
+	}
+	if len(p.txs) >= MaxPoolSize {
+		return fmt.Errorf("transaction pool is full")
+	}
+
+	p.txs[key] = tx
+	p.order = append(p.order, key)
+	return nil
+}
+
+// Get returns the queued transaction with the given hash, or nil
+func (p *TxPool) Get(hash []byte) *Transaction {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.txs[string(hash)]
+}
+
+// Pending returns the queued transactions in arrival order
+func (p *TxPool) Pending() []*Transaction {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	pending := make([]*Transaction, 0, len(p.order))
+	for _, key := range p.order {
+		pending = append(pending, p.txs[key])
+	}
+	return pending
+}
+
+// PendingByFeeRate returns the queued transactions ordered by fee per byte,
+// highest first. Transactions with equal rates keep their arrival order.
+func (p *TxPool) PendingByFeeRate() []*Transaction {
+	pending := p.Pending()
+	rates := make(map[*Transaction]float64, len(pending))
+	for _, tx := range pending {
+		rates[tx] = tx.FeeRate()
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return rates[pending[i]] > rates[pending[j]]
+	})
+	return pending
+}
+
+// Remove drops transactions that have been included in a block
+func (p *TxPool) Remove(txs []*Transaction) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, tx := range txs {
+		hash, err := tx.Hash()
+		if err != nil {
+			continue
+		}
+		delete(p.txs, string(hash))
+	}
+
+	order := p.order[:0]
+	for _, key := range p.order {
+		if _, ok := p.txs[key]; ok {
+			order = append(order, key)
+		}
+	}
+	p.order = order
+}
+
+// Size returns the number of pending transactions
+func (p *TxPool) Size() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.txs)
+}
@@ -0,0 +1,36 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPendingByFeeRate(t *testing.T) {
+	pending := func(timestamp int64, fee float64, data []byte) *Transaction {
+		return &Transaction{Sender: []byte("sender"), Receiver: []byte("receiver"), Amount: 1, Timestamp: timestamp, Fee: fee, Data: data}
+	}
+	noFee := pending(1700000001, 0, nil)
+	first := pending(1700000002, 2, nil)
+	second := pending(1700000003, 2, nil) // Same size and fee as first
+	large := pending(1700000004, 3, bytes.Repeat([]byte("x"), 1000))
+	best := pending(1700000005, 5, nil)
+
+	pool := NewTxPool()
+	for _, tx := range []*Transaction{noFee, first, second, large, best} {
+		if err := pool.Add(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []*Transaction{best, first, second, large, noFee}
+	got := pool.PendingByFeeRate()
+	if len(got) != len(want) {
+		t.Fatalf("PendingByFeeRate() returned %d transactions, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("position %d: fee %f, timestamp %d; want fee %f, timestamp %d",
+				i, got[i].Fee, got[i].Timestamp, want[i].Fee, want[i].Timestamp)
+		}
+	}
+}
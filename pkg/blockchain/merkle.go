@@ -1,9 +1,20 @@
 package blockchain
 
-import "crypto/sha256"
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+)
 
 type MerkleTree struct {
-	Root []byte
+	Root   []byte
+	Levels [][][]byte // Levels[0] holds the leaves, the last level holds the root
+}
+
+// MerkleProofStep is one sibling hash on the path from a leaf to the root
+type MerkleProofStep struct {
+	Hash []byte `json:"hash"`
+	Left bool   `json:"left"` // Sibling is the left operand of the pair
 }
 
 func NewMerkleTree(txHashes [][]byte) *MerkleTree {
@@ -12,6 +23,7 @@ func NewMerkleTree(txHashes [][]byte) *MerkleTree {
 	}
 
 	nodes := txHashes
+	levels := [][][]byte{nodes}
 
 	for len(nodes) > 1 {
 		var level [][]byte
@@ -26,16 +38,58 @@ func NewMerkleTree(txHashes [][]byte) *MerkleTree {
 				right = left
 			}
 
-			combined := append(left, right...)
-			hash := sha256.Sum256(combined)
-			level = append(level, hash[:])
+			level = append(level, hashPair(left, right))
 		}
 		nodes = level
+		levels = append(levels, nodes)
 	}
 
-	return &MerkleTree{Root: nodes[0]}
+	return &MerkleTree{Root: nodes[0], Levels: levels}
 }
 
 func (mt *MerkleTree) GetRoot() []byte {
 	return mt.Root
 }
+
+// Proof returns the sibling hashes needed to recompute the root from the
+// leaf at the given index
+func (mt *MerkleTree) Proof(index int) ([]MerkleProofStep, error) {
+	if len(mt.Levels) == 0 || index < 0 || index >= len(mt.Levels[0]) {
+		return nil, fmt.Errorf("leaf index %d out of range", index)
+	}
+
+	var proof []MerkleProofStep
+	for _, nodes := range mt.Levels[:len(mt.Levels)-1] {
+		sibling := index ^ 1
+		if sibling >= len(nodes) {
+			sibling = index // Odd node out is paired with itself
+		}
+		proof = append(proof, MerkleProofStep{
+			Hash: nodes[sibling],
+			Left: sibling < index,
+		})
+		index /= 2
+	}
+	return proof, nil
+}
+
+// VerifyMerkleProof checks that a leaf hash is included under a Merkle root
+func VerifyMerkleProof(leaf []byte, proof []MerkleProofStep, root []byte) bool {
+	current := leaf
+	for _, step := range proof {
+		if step.Left {
+			current = hashPair(step.Hash, current)
+		} else {
+			current = hashPair(current, step.Hash)
+		}
+	}
+	return bytes.Equal(current, root)
+}
+
+func hashPair(left, right []byte) []byte {
+	combined := make([]byte, 0, len(left)+len(right))
+	combined = append(combined, left...)
+	combined = append(combined, right...)
+	hash := sha256.Sum256(combined)
+	return hash[:]
+}
@@ -0,0 +1,83 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// NameRegistrationPeriod is how many blocks a registration or renewal
+	// lasts (about 30 days at one block every 10 seconds)
+	NameRegistrationPeriod = 259200
+
+	minNameLength = 3
+	maxNameLength = 32
+)
+
+// NameRecord maps a human-readable name to the address that owns it
+type NameRecord struct {
+	Name   string `json:"name"`
+	Owner  []byte `json:"owner"`
+	Expiry int    `json:"expiry"` // Last block height at which the name is valid
+}
+
+// Expired reports whether the record has lapsed at the given height
+func (r *NameRecord) Expired(height int) bool {
+	return height > r.Expiry
+}
+
+// NameOp is the payload of the name registry transactions.
+// The acting owner is the sender; a transfer's new owner is the receiver.
+type NameOp struct {
+	Name string `json:"name"`
+}
+
+// NormalizeName lower-cases a name and checks it is 3-32 characters of
+// a-z, 0-9 and '-'. Names can never be confused with 40-hex addresses.
+func NormalizeName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < minNameLength || len(name) > maxNameLength {
+		return "", fmt.Errorf("name must be %d-%d characters", minNameLength, maxNameLength)
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return "", fmt.Errorf("name %q must contain only a-z, 0-9 and '-'", name)
+		}
+	}
+	if name[0] == '-' || name[len(name)-1] == '-' {
+		return "", fmt.Errorf("name %q must not start or end with '-'", name)
+	}
+	return name, nil
+}
+
+// NewNameTransaction builds an unsigned register, renew or transfer
+// transaction. newOwner is only used for transfers.
+func NewNameTransaction(txType TxType, owner []byte, name string, newOwner []byte) (*Transaction, error) {
+	switch txType {
+	case TxNameRegister, TxNameRenew, TxNameTransfer:
+	default:
+		return nil, fmt.Errorf("%q is not a name transaction", txType)
+	}
+
+	name, err := NormalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(NameOp{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal name operation: %w", err)
+	}
+
+	tx := &Transaction{
+		Sender:    owner,
+		Timestamp: time.Now().Unix(),
+		Type:      txType,
+		Data:      data,
+	}
+	if txType == TxNameTransfer {
+		tx.Receiver = newOwner
+	}
+	return tx, nil
+}
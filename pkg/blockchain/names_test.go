@@ -0,0 +1,159 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+)
+
+// nameOp has the account sign a name register, renew or transfer
+func nameOp(t *testing.T, account *testAccount, txType TxType, name string, newOwner []byte) *Transaction {
+	t.Helper()
+	tx, err := NewNameTransaction(txType, nil, name, newOwner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account.sign(t, tx)
+}
+
+func TestApplyNameOps(t *testing.T) {
+	const expiry = 1 + NameRegistrationPeriod // Of a name registered at height 1
+
+	tests := []struct {
+		name       string
+		run        func(t *testing.T, s *stateTest) error
+		ok         bool
+		wantOwner  func(s *stateTest) []byte
+		wantExpiry int
+	}{
+		{
+			name: "register",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+			},
+			ok:         true,
+			wantOwner:  func(s *stateTest) []byte { return s.alice.address },
+			wantExpiry: expiry,
+		},
+		{
+			name: "register a taken name",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(2, nameOp(t, s.bob, TxNameRegister, "alice", nil))
+			},
+		},
+		{
+			name: "register a name on its last block",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(expiry, nameOp(t, s.bob, TxNameRegister, "alice", nil))
+			},
+		},
+		{
+			name: "register an expired name",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(expiry+1, nameOp(t, s.bob, TxNameRegister, "alice", nil))
+			},
+			ok:         true,
+			wantOwner:  func(s *stateTest) []byte { return s.bob.address },
+			wantExpiry: expiry + 1 + NameRegistrationPeriod,
+		},
+		{
+			name: "register a name that is not normalized",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, s.alice.sign(t, &Transaction{Type: TxNameRegister, Data: []byte(`{"name":"Alice"}`)}))
+			},
+		},
+		{
+			name: "register with an amount",
+			run: func(t *testing.T, s *stateTest) error {
+				tx, err := NewNameTransaction(TxNameRegister, nil, "alice", nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				tx.Amount = 1
+				return s.apply(1, s.alice.sign(t, tx))
+			},
+		},
+		{
+			name: "renew",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(2, nameOp(t, s.alice, TxNameRenew, "alice", nil))
+			},
+			ok:         true,
+			wantOwner:  func(s *stateTest) []byte { return s.alice.address },
+			wantExpiry: expiry + NameRegistrationPeriod,
+		},
+		{
+			name: "renew someone else's name",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(2, nameOp(t, s.bob, TxNameRenew, "alice", nil))
+			},
+		},
+		{
+			name: "renew an expired name",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(expiry+1, nameOp(t, s.alice, TxNameRenew, "alice", nil))
+			},
+		},
+		{
+			name: "renew an unregistered name",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, nameOp(t, s.alice, TxNameRenew, "alice", nil))
+			},
+		},
+		{
+			name: "transfer",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(2, nameOp(t, s.alice, TxNameTransfer, "alice", s.bob.address))
+			},
+			ok:         true,
+			wantOwner:  func(s *stateTest) []byte { return s.bob.address },
+			wantExpiry: expiry,
+		},
+		{
+			name: "transfer someone else's name",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(2, nameOp(t, s.bob, TxNameTransfer, "alice", s.bob.address))
+			},
+		},
+		{
+			name: "transfer without a new owner",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(2, nameOp(t, s.alice, TxNameTransfer, "alice", nil))
+			},
+		},
+		{
+			name: "transfer an expired name",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, nameOp(t, s.alice, TxNameRegister, "alice", nil))
+				return s.apply(expiry+1, nameOp(t, s.alice, TxNameTransfer, "alice", s.bob.address))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStateTest(t)
+			if err := tt.run(t, s); (err == nil) != tt.ok {
+				t.Fatalf("apply = %v, want ok=%t", err, tt.ok)
+			}
+			if tt.wantOwner == nil {
+				return
+			}
+			record, err := s.state.Name("alice")
+			if err != nil || record == nil {
+				t.Fatalf("Name() = %v, %v", record, err)
+			}
+			if !bytes.Equal(record.Owner, tt.wantOwner(s)) || record.Expiry != tt.wantExpiry {
+				t.Errorf("record = owner %x expiry %d, want owner %x expiry %d",
+					record.Owner, record.Expiry, tt.wantOwner(s), tt.wantExpiry)
+			}
+		})
+	}
+}
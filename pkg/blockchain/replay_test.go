@@ -0,0 +1,110 @@
+package blockchain_test
+
+import (
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/storage"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+)
+
+// replayChain opens an in-memory chain that funds one account
+func replayChain(t *testing.T, replayHeight int) (*blockchain.Blockchain, wallet.Signer) {
+	t.Helper()
+	signer, err := wallet.GenerateSigner(blockchain.SchemeP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc, err := blockchain.NewBlockchainWithGenesis(storage.NewMemory(), &blockchain.Genesis{
+		ChainID:      "test-chain",
+		Allocations:  []blockchain.GenesisAllocation{{Address: signer.Address(), Amount: 100}},
+		ReplayHeight: replayHeight,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bc, signer
+}
+
+func signedTransfer(t *testing.T, signer wallet.Signer, nonce uint64, chainID string) *blockchain.Transaction {
+	t.Helper()
+	tx := &blockchain.Transaction{
+		Sender:    signer.Address(),
+		Receiver:  make([]byte, blockchain.AddressLength),
+		Amount:    1,
+		Timestamp: 1700000000,
+		Nonce:     nonce,
+		ChainID:   chainID,
+	}
+	if err := wallet.SignTransactionWith(tx, signer); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+// addBlock commits the transactions in a block on top of the tip
+func addBlock(bc *blockchain.Blockchain, txs ...*blockchain.Transaction) error {
+	latest := bc.GetLatestBlock()
+	return bc.AddBlock(blockchain.NewBlock(latest.Index+1, txs, latest.CurrentBlockHash))
+}
+
+func TestReplayProtection(t *testing.T) {
+	tests := []struct {
+		name         string
+		replayHeight int
+		nonce        uint64
+		chainID      string
+		ok           bool
+	}{
+		{"next nonce", 0, 1, "test-chain", true},
+		{"no nonce", 0, 0, "test-chain", false},
+		{"nonce ahead", 0, 2, "test-chain", false},
+		{"no chain id", 0, 1, "", false},
+		{"other chain", 0, 1, "other-chain", false},
+		{"legacy block without nonce", 5, 0, "", true},
+		{"legacy block with wrong nonce", 5, 2, "", false},
+		{"legacy block with other chain", 5, 1, "other-chain", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bc, signer := replayChain(t, tt.replayHeight)
+			err := addBlock(bc, signedTransfer(t, signer, tt.nonce, tt.chainID))
+			if (err == nil) != tt.ok {
+				t.Errorf("AddBlock() = %v, want ok=%t", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCommittedTransactionCannotBeReplayed(t *testing.T) {
+	bc, signer := replayChain(t, 0)
+	tx := signedTransfer(t, signer, 1, "test-chain")
+	if err := bc.CheckTransaction(tx); err != nil {
+		t.Fatalf("CheckTransaction() before commit = %v", err)
+	}
+	if err := addBlock(bc, tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := bc.CheckTransaction(tx); err == nil {
+		t.Error("CheckTransaction accepted a committed transaction again")
+	}
+	if err := addBlock(bc, tx); err == nil {
+		t.Error("AddBlock applied a committed transaction again")
+	}
+}
+
+func TestNewTransactionsGetNoLegacyExemption(t *testing.T) {
+	bc, signer := replayChain(t, 5)
+	tx := signedTransfer(t, signer, 0, "")
+	if err := bc.CheckTransaction(tx); err == nil {
+		t.Error("CheckTransaction accepted a transaction without nonce or chain ID")
+	}
+	if selected := bc.SelectTransactions([]*blockchain.Transaction{tx}, 10); len(selected) != 0 {
+		t.Error("SelectTransactions picked a transaction without nonce or chain ID")
+	}
+
+	// Blocks from before replay protection still apply
+	if err := addBlock(bc, tx); err != nil {
+		t.Fatalf("legacy block: %v", err)
+	}
+}
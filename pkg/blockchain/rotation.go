@@ -0,0 +1,76 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// rotationDomain separates the new key's proof of possession from every
+// other signature
+const rotationDomain = "blockchain-go/rotate-key"
+
+// KeyRotation is the payload of a TxRotateKey transaction: the key that
+// signs for the sender from now on, and that key's signature of
+// RotationDigest(sender) proving its holder agreed to the rotation
+type KeyRotation struct {
+	Scheme    SignatureScheme `json:"scheme,omitempty"`
+	PublicKey []byte          `json:"public_key"`
+	Proof     []byte          `json:"proof"`
+}
+
+// AuthorizedKey is the key an address was last rotated to, or the key that
+// first signed for it (see State.PublicKey)
+type AuthorizedKey struct {
+	Scheme    SignatureScheme `json:"scheme,omitempty"`
+	PublicKey []byte          `json:"public_key"`
+	Height    int             `json:"height"` // Block that committed the rotation or revealed the key
+}
+
+// RotationDigest returns the hash the new key signs to accept authority
+// over an address
+func RotationDigest(address []byte) []byte {
+	data := append([]byte(rotationDomain), 0)
+	data = append(data, address...)
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// NewRotateKeyTransaction builds an unsigned transaction handing the
+// authority to sign for address over to a new key. The transaction must be
+// signed by the address's current key.
+func NewRotateKeyTransaction(address []byte, rotation KeyRotation) (*Transaction, error) {
+	data, err := json.Marshal(rotation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal key rotation: %w", err)
+	}
+	return &Transaction{
+		Sender:    address,
+		Timestamp: time.Now().Unix(),
+		Type:      TxRotateKey,
+		Data:      data,
+	}, nil
+}
+
+// keyRotation decodes and checks the payload of a TxRotateKey transaction
+func (t *Transaction) keyRotation() (*KeyRotation, error) {
+	var rotation KeyRotation
+	if err := json.Unmarshal(t.Data, &rotation); err != nil {
+		return nil, fmt.Errorf("invalid key rotation payload: %w", err)
+	}
+	verifier, err := ParseVerifier(rotation.Scheme, rotation.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid new key: %w", err)
+	}
+	if err := verifier.Verify(RotationDigest(t.Sender), rotation.Proof); err != nil {
+		return nil, fmt.Errorf("new key did not sign the rotation: %w", err)
+	}
+	return &rotation, nil
+}
+
+// Authorizes reports whether key is the authorized key of the given scheme
+func (k *AuthorizedKey) Authorizes(scheme SignatureScheme, pubKey []byte) bool {
+	return k.Scheme == scheme && bytes.Equal(k.PublicKey, pubKey)
+}
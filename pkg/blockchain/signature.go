@@ -0,0 +1,221 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Signature checks live in this package, rather than in pkg/wallet, so that
+// every node can verify transactions while applying blocks.
+
+// SignatureLength is the size of an encoded signature: r and s as 32-byte
+// big-endian integers, r first
+const SignatureLength = 64
+
+// PublicKeyLength is the size of a compressed P-256 public key
+const PublicKeyLength = 33
+
+var (
+	// ErrMissingSignature is returned when verifying an unsigned transaction
+	ErrMissingSignature = errors.New("missing signature")
+
+	// ErrInvalidSignature is returned when a signature does not match
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrHighS is returned for signatures whose s is not in the lower half of
+	// the curve order. Only low-S signatures are accepted, so a valid
+	// signature cannot be rewritten into a second valid one.
+	ErrHighS = errors.New("signature s value is not canonical (high S)")
+)
+
+var (
+	curveOrder     = elliptic.P256().Params().N
+	halfCurveOrder = new(big.Int).Rsh(curveOrder, 1)
+)
+
+// SignatureScheme identifies the key type and signature algorithm that
+// authorize a transaction. The zero value is the original ECDSA P-256
+// scheme so that existing transactions keep their meaning and hash.
+type SignatureScheme string
+
+const (
+	SchemeP256    SignatureScheme = ""        // ECDSA P-256 over SHA-256, low-S
+	SchemeEd25519 SignatureScheme = "ed25519" // Ed25519 (RFC 8032)
+)
+
+// Verifier checks signatures made with one public key
+type Verifier interface {
+	Scheme() SignatureScheme
+	PublicKey() []byte // Encoded public key as carried in transactions
+	Address() []byte
+	Verify(hash, sig []byte) error
+}
+
+// ParseVerifier decodes a public key of the given scheme
+func ParseVerifier(scheme SignatureScheme, pubKey []byte) (Verifier, error) {
+	switch scheme {
+	case SchemeP256:
+		key, err := ParsePublicKey(pubKey)
+		if err != nil {
+			return nil, err
+		}
+		return &p256Verifier{key: key}, nil
+	case SchemeEd25519:
+		if len(pubKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 public key length %d, want %d", len(pubKey), ed25519.PublicKeySize)
+		}
+		return ed25519Verifier(pubKey), nil
+	default:
+		return nil, fmt.Errorf("unknown signature scheme %q", scheme)
+	}
+}
+
+// SchemeAddress derives the address of an encoded public key, or returns
+// nil if the key does not decode. P-256 addresses keep the original
+// derivation, a hash of the uncompressed coordinates, so existing accounts
+// keep their address. Other schemes hash the scheme name with the key, so an
+// address can only be spent with keys of the scheme it was created for.
+func SchemeAddress(scheme SignatureScheme, pubKey []byte) []byte {
+	var hash [sha256.Size]byte
+	switch scheme {
+	case SchemeP256:
+		key, err := ParsePublicKey(pubKey)
+		if err != nil {
+			return nil
+		}
+		hash = sha256.Sum256(append(key.X.Bytes(), key.Y.Bytes()...))
+	default:
+		data := append([]byte(scheme), 0)
+		hash = sha256.Sum256(append(data, pubKey...))
+	}
+	return hash[:AddressLength]
+}
+
+// AddressFromPublicKey derives the account address of a P-256 public key
+func AddressFromPublicKey(pubKey *ecdsa.PublicKey) []byte {
+	return SchemeAddress(SchemeP256, MarshalPublicKey(pubKey))
+}
+
+// MarshalPublicKey encodes a P-256 public key in compressed form
+func MarshalPublicKey(pubKey *ecdsa.PublicKey) []byte {
+	return elliptic.MarshalCompressed(elliptic.P256(), pubKey.X, pubKey.Y)
+}
+
+// ParsePublicKey decodes a compressed P-256 public key
+func ParsePublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	if len(data) != PublicKeyLength {
+		return nil, fmt.Errorf("invalid public key length %d, want %d", len(data), PublicKeyLength)
+	}
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), data)
+	if x == nil {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
+
+type p256Verifier struct {
+	key *ecdsa.PublicKey
+}
+
+func (v *p256Verifier) Scheme() SignatureScheme { return SchemeP256 }
+func (v *p256Verifier) PublicKey() []byte       { return MarshalPublicKey(v.key) }
+func (v *p256Verifier) Address() []byte         { return SchemeAddress(SchemeP256, v.PublicKey()) }
+
+func (v *p256Verifier) Verify(hash, sig []byte) error {
+	return VerifySignature(hash, sig, v.key)
+}
+
+type ed25519Verifier ed25519.PublicKey
+
+func (v ed25519Verifier) Scheme() SignatureScheme { return SchemeEd25519 }
+func (v ed25519Verifier) PublicKey() []byte       { return []byte(v) }
+func (v ed25519Verifier) Address() []byte         { return SchemeAddress(SchemeEd25519, v.PublicKey()) }
+
+func (v ed25519Verifier) Verify(hash, sig []byte) error {
+	if len(sig) == 0 {
+		return ErrMissingSignature
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: length %d, want %d", ErrInvalidSignature, len(sig), ed25519.SignatureSize)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(v), hash, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// IsLowS reports whether s is in the lower half of the curve order
+func IsLowS(s *big.Int) bool {
+	return s.Cmp(halfCurveOrder) <= 0
+}
+
+// NormalizeS returns the low-S form of s
+func NormalizeS(s *big.Int) *big.Int {
+	if IsLowS(s) {
+		return s
+	}
+	return new(big.Int).Sub(curveOrder, s)
+}
+
+// VerifySignature checks a 64-byte low-S P-256 signature of a hash
+func VerifySignature(hash, sig []byte, pubKey *ecdsa.PublicKey) error {
+	if len(sig) == 0 {
+		return ErrMissingSignature
+	}
+	if len(sig) != SignatureLength {
+		return fmt.Errorf("%w: length %d, want %d", ErrInvalidSignature, len(sig), SignatureLength)
+	}
+	if pubKey == nil || pubKey.X == nil || pubKey.Y == nil {
+		return fmt.Errorf("%w: missing public key", ErrInvalidSignature)
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if r.Sign() == 0 || s.Sign() == 0 || r.Cmp(curveOrder) >= 0 {
+		return fmt.Errorf("%w: value out of range", ErrInvalidSignature)
+	}
+	if !IsLowS(s) {
+		return ErrHighS
+	}
+	if !ecdsa.Verify(pubKey, hash, r, s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifySignature checks that the embedded public key signed the
+// transaction. Whether that key may sign for the sender depends on the state
+// (see State.AuthorizedKey) and is checked when the transaction is applied.
+// Coinbase transactions are not signed.
+func (t *Transaction) VerifySignature() error {
+	if t.IsCoinbase() {
+		return nil
+	}
+	if len(t.PublicKey) == 0 {
+		return fmt.Errorf("missing sender public key")
+	}
+
+	verifier, err := ParseVerifier(t.Scheme, t.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	hash, err := t.Hash()
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(hash, t.Signature)
+}
+
+// SignedByOwnKey reports whether the embedded public key is the one the
+// sender address was derived from
+func (t *Transaction) SignedByOwnKey() bool {
+	verifier, err := ParseVerifier(t.Scheme, t.PublicKey)
+	return err == nil && bytes.Equal(verifier.Address(), t.Sender)
+}
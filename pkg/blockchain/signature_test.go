@@ -0,0 +1,181 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// signHash makes a fixed-width signature with the given s, low or high
+func signHash(t *testing.T, key *ecdsa.PrivateKey, hash []byte, highS bool) []byte {
+	t.Helper()
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = NormalizeS(s)
+	if highS {
+		s = new(big.Int).Sub(curveOrder, s)
+	}
+	sig := make([]byte, SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte("transaction"))
+	sig := signHash(t, key, hash[:], false)
+	highS := signHash(t, key, hash[:], true)
+
+	// The high-S form is a valid ECDSA signature, just not a canonical one
+	r, s := new(big.Int).SetBytes(highS[:32]), new(big.Int).SetBytes(highS[32:])
+	if !ecdsa.Verify(&key.PublicKey, hash[:], r, s) {
+		t.Fatal("high-S signature does not verify with crypto/ecdsa")
+	}
+
+	der, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	zeroR := append(make([]byte, 32), sig[32:]...)
+	rTooLarge := append(curveOrder.FillBytes(make([]byte, 32)), sig[32:]...)
+	otherHash := sha256.Sum256([]byte("other transaction"))
+
+	tests := []struct {
+		name string
+		hash []byte
+		sig  []byte
+		want error
+	}{
+		{"valid", hash[:], sig, nil},
+		{"high s", hash[:], highS, ErrHighS},
+		{"empty", hash[:], nil, ErrMissingSignature},
+		{"too short", hash[:], sig[:63], ErrInvalidSignature},
+		{"too long", hash[:], append(sig[:64:64], 0), ErrInvalidSignature},
+		{"der encoded", hash[:], der, ErrInvalidSignature},
+		{"zero r", hash[:], zeroR, ErrInvalidSignature},
+		{"r not below n", hash[:], rTooLarge, ErrInvalidSignature},
+		{"other hash", otherHash[:], sig, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.hash, tt.sig, &key.PublicKey)
+			if tt.want == nil && err != nil {
+				t.Fatalf("VerifySignature() = %v, want nil", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("VerifySignature() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeS(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *big.Int
+		want *big.Int
+	}{
+		{"one", big.NewInt(1), big.NewInt(1)},
+		{"half n", halfCurveOrder, halfCurveOrder},
+		{"half n + 1", new(big.Int).Add(halfCurveOrder, big.NewInt(1)), halfCurveOrder},
+		{"n - 1", new(big.Int).Sub(curveOrder, big.NewInt(1)), big.NewInt(1)},
+	}
+	for _, tt := range tests {
+		got := NormalizeS(tt.s)
+		if got.Cmp(tt.want) != 0 || !IsLowS(got) {
+			t.Errorf("NormalizeS(%s) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionVerifySignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := func(edit func(tx *Transaction)) *Transaction {
+		tx := &Transaction{
+			Sender:    AddressFromPublicKey(&key.PublicKey),
+			Receiver:  AddressFromPublicKey(&other.PublicKey),
+			Amount:    5,
+			Timestamp: 1700000000,
+			PublicKey: MarshalPublicKey(&key.PublicKey),
+		}
+		hash, err := tx.Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx.Signature = signHash(t, key, hash, false)
+		if edit != nil {
+			edit(tx)
+		}
+		return tx
+	}
+
+	tests := []struct {
+		name   string
+		tx     *Transaction
+		ok     bool
+		ownKey bool
+	}{
+		{"valid", signed(nil), true, true},
+		{"amount changed", signed(func(tx *Transaction) { tx.Amount = 500 }), false, true},
+		{"receiver changed", signed(func(tx *Transaction) { tx.Receiver = tx.Sender }), false, true},
+		{"other key embedded", signed(func(tx *Transaction) { tx.PublicKey = MarshalPublicKey(&other.PublicKey) }), false, false},
+		{"no public key", signed(func(tx *Transaction) { tx.PublicKey = nil }), false, false},
+		{"truncated public key", signed(func(tx *Transaction) { tx.PublicKey = tx.PublicKey[:32] }), false, false},
+		{"unknown scheme", signed(func(tx *Transaction) { tx.Scheme = "rsa" }), false, false},
+		{"sender of another key", signed(func(tx *Transaction) { tx.Sender = tx.Receiver }), false, false},
+		{"coinbase", &Transaction{Sender: ConsensusSender, Receiver: []byte("proposer"), Amount: BlockReward}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tx.VerifySignature(); (err == nil) != tt.ok {
+				t.Errorf("VerifySignature() = %v, want ok=%t", err, tt.ok)
+			}
+			if got := tt.tx.SignedByOwnKey(); got != tt.ownKey {
+				t.Errorf("SignedByOwnKey() = %t, want %t", got, tt.ownKey)
+			}
+		})
+	}
+}
+
+func TestSchemeAddress(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := MarshalPublicKey(&key.PublicKey)
+
+	// P-256 addresses hash the uncompressed coordinates, as they always have
+	legacy := sha256.Sum256(append(key.X.Bytes(), key.Y.Bytes()...))
+	if got := SchemeAddress(SchemeP256, pubKey); !bytes.Equal(got, legacy[:AddressLength]) {
+		t.Errorf("P-256 address %x, want %x", got, legacy[:AddressLength])
+	}
+	if got := AddressFromPublicKey(&key.PublicKey); !bytes.Equal(got, legacy[:AddressLength]) {
+		t.Errorf("AddressFromPublicKey = %x, want %x", got, legacy[:AddressLength])
+	}
+
+	// The same bytes under another scheme give another address
+	if bytes.Equal(SchemeAddress(SchemeEd25519, pubKey), legacy[:AddressLength]) {
+		t.Error("Ed25519 and P-256 derive the same address from one key")
+	}
+	if got := SchemeAddress(SchemeP256, pubKey[:32]); got != nil {
+		t.Errorf("address %x for a truncated P-256 key", got)
+	}
+}
@@ -0,0 +1,862 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// State key layout
+const (
+	balancePrefix     = "balance_"
+	assetPrefix       = "asset_"
+	assetSymbolPrefix = "asset_symbol_"
+	assetIndexKey     = "asset_index"
+	namePrefix        = "name_"
+	anchorPrefix      = "anchor_"
+	allowancePrefix   = "allowance_"
+	allowanceIndex    = "allowance_index_"
+	vestingPrefix     = "vesting_"
+	noncePrefix       = "nonce_"
+	authKeyPrefix     = "authkey_"
+	pubKeyPrefix      = "pubkey_"
+	chainIDKey        = "chain_id"
+	replayHeightKey   = "replay_height" // See Genesis.ReplayHeight
+)
+
+func balanceKey(address []byte, asset string) string {
+	return fmt.Sprintf("%s%x_%s", balancePrefix, address, asset)
+}
+
+func assetKey(id string) string {
+	return assetPrefix + id
+}
+
+func assetSymbolKey(symbol string) string {
+	return assetSymbolPrefix + symbol
+}
+
+func nameKey(name string) string {
+	return namePrefix + name
+}
+
+func anchorKey(hash []byte) string {
+	return fmt.Sprintf("%s%x", anchorPrefix, hash)
+}
+
+func allowanceKey(owner, spender []byte, asset string) string {
+	return fmt.Sprintf("%s%x_%x_%s", allowancePrefix, owner, spender, asset)
+}
+
+func allowanceIndexKey(owner []byte) string {
+	return fmt.Sprintf("%s%x", allowanceIndex, owner)
+}
+
+func vestingKey(address []byte) string {
+	return fmt.Sprintf("%s%x", vestingPrefix, address)
+}
+
+func nonceKey(address []byte) string {
+	return fmt.Sprintf("%s%x", noncePrefix, address)
+}
+
+func authKeyKey(address []byte) string {
+	return fmt.Sprintf("%s%x", authKeyPrefix, address)
+}
+
+func pubKeyKey(address []byte) string {
+	return fmt.Sprintf("%s%x", pubKeyPrefix, address)
+}
+
+// State holds the account balances and asset registry derived from the
+// committed blocks. It is persisted in the same storage as the blocks.
+type State struct {
+	storage Storage
+}
+
+// NewState creates a state backed by the given storage
+func NewState(storage Storage) *State {
+	return &State{storage: storage}
+}
+
+// Balance returns the balance of an address for an asset
+func (s *State) Balance(address []byte, asset string) (float64, error) {
+	return s.newView(0).balance(address, asset)
+}
+
+// Asset returns the asset with the given ID, or nil if it does not exist
+func (s *State) Asset(id string) (*Asset, error) {
+	return s.newView(0).asset(id)
+}
+
+// Assets returns every issued asset in issuance order
+func (s *State) Assets() ([]*Asset, error) {
+	view := s.newView(0)
+
+	ids, err := view.assetIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	assets := make([]*Asset, 0, len(ids))
+	for _, id := range ids {
+		asset, err := view.asset(id)
+		if err != nil {
+			return nil, err
+		}
+		if asset != nil {
+			assets = append(assets, asset)
+		}
+	}
+	return assets, nil
+}
+
+// Name returns the registry record for a name, or nil if it was never registered
+func (s *State) Name(name string) (*NameRecord, error) {
+	return s.newView(0).name(name)
+}
+
+// Anchor returns where a hash was anchored, or nil if it never was
+func (s *State) Anchor(hash []byte) (*AnchorRecord, error) {
+	var record AnchorRecord
+	found, err := s.newView(0).getJSON(anchorKey(hash), &record)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Allowances returns the non-zero allowances granted by an owner
+func (s *State) Allowances(owner []byte) ([]*Allowance, error) {
+	view := s.newView(0)
+
+	var grants []*Allowance
+	if _, err := view.getJSON(allowanceIndexKey(owner), &grants); err != nil {
+		return nil, err
+	}
+
+	var allowances []*Allowance
+	for _, grant := range grants {
+		amount, err := view.allowance(owner, grant.Spender, grant.Asset)
+		if err != nil {
+			return nil, err
+		}
+		if amount > 0 {
+			grant.Amount = amount
+			allowances = append(allowances, grant)
+		}
+	}
+	return allowances, nil
+}
+
+// Nonce returns the number of transactions an address has sent
+func (s *State) Nonce(address []byte) (uint64, error) {
+	return s.newView(0).nonce(address)
+}
+
+// AuthorizedKey returns the key an address was rotated to, or nil if it is
+// still controlled by the key it was derived from
+func (s *State) AuthorizedKey(address []byte) (*AuthorizedKey, error) {
+	return s.newView(0).authorizedKey(address)
+}
+
+// PublicKey returns the key that currently signs for an address: the key it
+// was rotated to, or else the key its first transaction revealed. It is nil
+// for addresses that never sent a transaction.
+func (s *State) PublicKey(address []byte) (*AuthorizedKey, error) {
+	v := s.newView(0)
+	key, err := v.authorizedKey(address)
+	if err != nil || key != nil {
+		return key, err
+	}
+	var revealed AuthorizedKey
+	found, err := v.getJSON(pubKeyKey(address), &revealed)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &revealed, nil
+}
+
+// ChainID returns the identifier of the chain recorded at genesis
+func (s *State) ChainID() string {
+	id, _ := s.newView(0).get(chainIDKey)
+	return string(id)
+}
+
+// Vesting returns the vesting status of an address at the given height
+func (s *State) Vesting(address []byte, height int) (*VestingStatus, error) {
+	view := s.newView(height)
+
+	balance, err := view.balance(address, NativeAsset)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := view.vestingSchedules(address)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &VestingStatus{
+		Address:   address,
+		Height:    height,
+		Balance:   balance,
+		Schedules: schedules,
+	}
+	for _, schedule := range schedules {
+		status.Vested += schedule.Vested(height)
+		status.Locked += schedule.Locked(height)
+	}
+	return status, nil
+}
+
+// newView starts a buffered view of the state for applying transactions
+// at the given block height
+func (s *State) newView(height int) *stateView {
+	return &stateView{
+		storage: s.storage,
+		writes:  make(map[string][]byte),
+		height:  height,
+	}
+}
+
+// stateView buffers state writes made while applying transactions so that a
+// block is either applied completely or not at all
+type stateView struct {
+	storage Storage
+	parent  *stateView // Reads fall through to the parent view when set
+	writes  map[string][]byte
+	keys    []string // Write order, so commits are deterministic
+	height  int
+	txIndex int // Position in the block of the transaction being applied
+
+	// futureNonces accepts nonces past the sender's next one, for checking
+	// transactions that will queue behind others still pending
+	futureNonces bool
+	// newTransactions marks transactions submitted or picked for a new
+	// block, which get no legacy replay exemption whatever the height
+	newTransactions bool
+}
+
+func (v *stateView) get(key string) ([]byte, bool) {
+	if value, ok := v.writes[key]; ok {
+		return value, true
+	}
+	if v.parent != nil {
+		return v.parent.get(key)
+	}
+	value, err := v.storage.Get(key)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (v *stateView) put(key string, value []byte) {
+	if _, ok := v.writes[key]; !ok {
+		v.keys = append(v.keys, key)
+	}
+	v.writes[key] = value
+}
+
+func (v *stateView) getJSON(key string, out interface{}) (bool, error) {
+	data, ok := v.get(key)
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return false, fmt.Errorf("failed to unmarshal state key %s: %w", key, err)
+	}
+	return true, nil
+}
+
+func (v *stateView) putJSON(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state key %s: %w", key, err)
+	}
+	v.put(key, data)
+	return nil
+}
+
+// child starts a nested view whose changes can be merged into v or dropped
+func (v *stateView) child() *stateView {
+	return &stateView{
+		parent:          v,
+		writes:          make(map[string][]byte),
+		height:          v.height,
+		futureNonces:    v.futureNonces,
+		newTransactions: v.newTransactions,
+	}
+}
+
+// merge folds the changes of a child view into its parent
+func (v *stateView) merge() {
+	for _, key := range v.keys {
+		v.parent.put(key, v.writes[key])
+	}
+}
+
+// StateChange is a state key written by a block
+type StateChange struct {
+	Key   string
+	Value []byte
+}
+
+// changes returns the buffered writes in order, to be stored with the block
+// that made them
+func (v *stateView) changes() []StateChange {
+	changes := make([]StateChange, 0, len(v.keys))
+	for _, key := range v.keys {
+		changes = append(changes, StateChange{Key: key, Value: v.writes[key]})
+	}
+	return changes
+}
+
+func (v *stateView) balance(address []byte, asset string) (float64, error) {
+	var balance float64
+	if _, err := v.getJSON(balanceKey(address, asset), &balance); err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+func (v *stateView) credit(address []byte, asset string, amount float64) error {
+	if len(address) == 0 {
+		return fmt.Errorf("missing receiver")
+	}
+	balance, err := v.balance(address, asset)
+	if err != nil {
+		return err
+	}
+	return v.putJSON(balanceKey(address, asset), balance+amount)
+}
+
+func (v *stateView) debit(address []byte, asset string, amount float64) error {
+	balance, err := v.balance(address, asset)
+	if err != nil {
+		return err
+	}
+	if balance < amount {
+		return fmt.Errorf("insufficient %s balance for %x: have %f, need %f",
+			assetLabel(asset), address, balance, amount)
+	}
+
+	// Coins still locked by a vesting schedule cannot be spent
+	if asset == NativeAsset {
+		locked, err := v.locked(address)
+		if err != nil {
+			return err
+		}
+		if balance-locked < amount {
+			return fmt.Errorf("insufficient spendable %s for %x: have %f (%f locked), need %f",
+				assetLabel(asset), address, balance-locked, locked, amount)
+		}
+	}
+	return v.putJSON(balanceKey(address, asset), balance-amount)
+}
+
+func (v *stateView) asset(id string) (*Asset, error) {
+	var asset Asset
+	found, err := v.getJSON(assetKey(id), &asset)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (v *stateView) assetIndex() ([]string, error) {
+	var ids []string
+	if _, err := v.getJSON(assetIndexKey, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// applyBlock applies every transaction of a block in order
+func (v *stateView) applyBlock(block *Block) error {
+	var minted, fees float64
+	for i, tx := range block.Transactions {
+		v.txIndex = i
+		if err := v.applyTransaction(tx); err != nil {
+			return fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		if !tx.IsCoinbase() {
+			fees += tx.Fee
+			continue
+		}
+		if block.Index > 0 && string(tx.Sender) != string(ConsensusSender) {
+			return fmt.Errorf("transaction %d: genesis allocations are only allowed in block 0", i)
+		}
+		minted += tx.Amount
+	}
+
+	// The proposer may claim the block reward plus the fees it collected
+	if block.Index > 0 && minted > BlockReward+fees {
+		return fmt.Errorf("block mints %f, more than reward %f plus fees %f", minted, BlockReward, fees)
+	}
+	return nil
+}
+
+// applyTransaction validates a transaction against the current view and
+// records its effects
+func (v *stateView) applyTransaction(tx *Transaction) error {
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+
+	if tx.IsCoinbase() {
+		// Genesis allocations may be locked under a vesting schedule
+		if tx.Type == TxVestingGrant && string(tx.Sender) == string(GenesisSender) {
+			return v.applyVestingGrant(tx)
+		}
+		if tx.Type != TxTransfer || tx.Asset != NativeAsset {
+			return fmt.Errorf("coinbase transactions can only mint %s", NativeAssetSymbol)
+		}
+		return v.credit(tx.Receiver, NativeAsset, tx.Amount)
+	}
+
+	if err := v.checkAuthorized(tx); err != nil {
+		return err
+	}
+	if err := v.checkReplay(tx); err != nil {
+		return err
+	}
+
+	// Fees are always paid in the native coin
+	if tx.Fee > 0 {
+		if err := v.debit(tx.Sender, NativeAsset, tx.Fee); err != nil {
+			return fmt.Errorf("cannot pay fee: %w", err)
+		}
+	}
+
+	switch tx.Type {
+	case TxTransfer:
+		return v.applyTransfer(tx)
+	case TxIssueAsset:
+		return v.applyIssueAsset(tx)
+	case TxNameRegister, TxNameRenew, TxNameTransfer:
+		return v.applyNameOp(tx)
+	case TxAnchor:
+		return v.applyAnchor(tx)
+	case TxApprove:
+		return v.applyApprove(tx)
+	case TxTransferFrom:
+		return v.applyTransferFrom(tx)
+	case TxVestingGrant:
+		if tx.Amount < MinVestingGrant {
+			return fmt.Errorf("vesting grants must be at least %g %s", MinVestingGrant, NativeAssetSymbol)
+		}
+		if err := v.debit(tx.Sender, NativeAsset, tx.Amount); err != nil {
+			return err
+		}
+		return v.applyVestingGrant(tx)
+	case TxRotateKey:
+		return v.applyRotateKey(tx)
+	default:
+		return fmt.Errorf("unknown transaction type %q", tx.Type)
+	}
+}
+
+func (v *stateView) authorizedKey(address []byte) (*AuthorizedKey, error) {
+	var key AuthorizedKey
+	found, err := v.getJSON(authKeyKey(address), &key)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// checkAuthorized checks that the key that signed a transaction may sign for
+// its sender: the key the sender was last rotated to or, if it never was,
+// the key the sender address was derived from
+func (v *stateView) checkAuthorized(tx *Transaction) error {
+	key, err := v.authorizedKey(tx.Sender)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		if !tx.SignedByOwnKey() {
+			return fmt.Errorf("public key does not match sender %x", tx.Sender)
+		}
+		return v.revealPublicKey(tx)
+	}
+	if !key.Authorizes(tx.Scheme, tx.PublicKey) {
+		return fmt.Errorf("key of %x was rotated at height %d; sign with the current key", tx.Sender, key.Height)
+	}
+	return nil
+}
+
+// revealPublicKey records the key behind an address the first time it signs,
+// so that others can encrypt memos to it
+func (v *stateView) revealPublicKey(tx *Transaction) error {
+	if _, ok := v.get(pubKeyKey(tx.Sender)); ok {
+		return nil
+	}
+	return v.putJSON(pubKeyKey(tx.Sender), &AuthorizedKey{
+		Scheme:    tx.Scheme,
+		PublicKey: tx.PublicKey,
+		Height:    v.height,
+	})
+}
+
+// applyRotateKey makes the transaction's new key the only one that can sign
+// for the sender. The address stays the same.
+func (v *stateView) applyRotateKey(tx *Transaction) error {
+	rotation, err := tx.keyRotation()
+	if err != nil {
+		return err
+	}
+	return v.putJSON(authKeyKey(tx.Sender), &AuthorizedKey{
+		Scheme:    rotation.Scheme,
+		PublicKey: rotation.PublicKey,
+		Height:    v.height,
+	})
+}
+
+func (v *stateView) nonce(address []byte) (uint64, error) {
+	var nonce uint64
+	if _, err := v.getJSON(nonceKey(address), &nonce); err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// replayHeight returns the first block height at which transactions must
+// carry a chain ID and nonce
+func (v *stateView) replayHeight() (int, error) {
+	var height int
+	if _, err := v.getJSON(replayHeightKey, &height); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// checkReplay checks the chain ID and nonce of a transaction and counts it
+// against its sender, so the next nonce of an address is always its
+// transaction count plus one. Only blocks below the replay height, committed
+// before replay protection existed, may hold transactions without them.
+func (v *stateView) checkReplay(tx *Transaction) error {
+	legacy := false
+	if !v.newTransactions {
+		replayHeight, err := v.replayHeight()
+		if err != nil {
+			return err
+		}
+		legacy = v.height < replayHeight
+	}
+
+	if tx.ChainID != "" || !legacy {
+		if chainID, _ := v.get(chainIDKey); tx.ChainID != string(chainID) {
+			return fmt.Errorf("transaction is for chain %q, not %q", tx.ChainID, chainID)
+		}
+	}
+
+	nonce, err := v.nonce(tx.Sender)
+	if err != nil {
+		return err
+	}
+	if (tx.Nonce != 0 || !legacy) && tx.Nonce != nonce+1 && !(v.futureNonces && tx.Nonce > nonce) {
+		return fmt.Errorf("invalid nonce %d for %x: next nonce is %d", tx.Nonce, tx.Sender, nonce+1)
+	}
+	return v.putJSON(nonceKey(tx.Sender), nonce+1)
+}
+
+func (v *stateView) applyTransfer(tx *Transaction) error {
+	if err := v.checkAsset(tx.Asset); err != nil {
+		return err
+	}
+
+	if err := v.debit(tx.Sender, tx.Asset, tx.Amount); err != nil {
+		return err
+	}
+	return v.credit(tx.Receiver, tx.Asset, tx.Amount)
+}
+
+// checkAsset verifies that an asset ID refers to the native coin or an issued asset
+func (v *stateView) checkAsset(id string) error {
+	if id == NativeAsset {
+		return nil
+	}
+	asset, err := v.asset(id)
+	if err != nil {
+		return err
+	}
+	if asset == nil {
+		return fmt.Errorf("unknown asset %s", id)
+	}
+	return nil
+}
+
+func (v *stateView) applyIssueAsset(tx *Transaction) error {
+	var issue AssetIssue
+	if err := json.Unmarshal(tx.Data, &issue); err != nil {
+		return fmt.Errorf("invalid asset issue payload: %w", err)
+	}
+	if err := issue.validate(); err != nil {
+		return err
+	}
+	if tx.Asset != NativeAsset {
+		return fmt.Errorf("issue transactions must not name an asset")
+	}
+
+	id, err := AssetID(tx)
+	if err != nil {
+		return err
+	}
+	if existing, err := v.asset(id); err != nil || existing != nil {
+		return fmt.Errorf("asset %s already exists", id)
+	}
+	if _, taken := v.get(assetSymbolKey(issue.Symbol)); taken {
+		return fmt.Errorf("asset symbol %s is already in use", issue.Symbol)
+	}
+
+	asset := &Asset{
+		ID:       id,
+		Symbol:   issue.Symbol,
+		Decimals: issue.Decimals,
+		Supply:   tx.Amount,
+		Issuer:   tx.Sender,
+		Height:   v.height,
+	}
+	if err := v.putJSON(assetKey(id), asset); err != nil {
+		return err
+	}
+	v.put(assetSymbolKey(issue.Symbol), []byte(id))
+
+	ids, err := v.assetIndex()
+	if err != nil {
+		return err
+	}
+	if err := v.putJSON(assetIndexKey, append(ids, id)); err != nil {
+		return err
+	}
+
+	return v.credit(tx.Sender, id, tx.Amount)
+}
+
+func (v *stateView) name(name string) (*NameRecord, error) {
+	var record NameRecord
+	found, err := v.getJSON(nameKey(name), &record)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (v *stateView) applyNameOp(tx *Transaction) error {
+	var op NameOp
+	if err := json.Unmarshal(tx.Data, &op); err != nil {
+		return fmt.Errorf("invalid name payload: %w", err)
+	}
+	name, err := NormalizeName(op.Name)
+	if err != nil {
+		return err
+	}
+	if name != op.Name {
+		return fmt.Errorf("name %q is not normalized", op.Name)
+	}
+
+	record, err := v.name(name)
+	if err != nil {
+		return err
+	}
+
+	switch tx.Type {
+	case TxNameRegister:
+		if record != nil && !record.Expired(v.height) {
+			return fmt.Errorf("name %s is already registered until block %d", name, record.Expiry)
+		}
+		record = &NameRecord{Name: name, Owner: tx.Sender}
+		record.Expiry = v.height + NameRegistrationPeriod
+
+	case TxNameRenew:
+		if err := checkNameOwner(record, name, tx.Sender, v.height); err != nil {
+			return err
+		}
+		record.Expiry += NameRegistrationPeriod
+
+	case TxNameTransfer:
+		if err := checkNameOwner(record, name, tx.Sender, v.height); err != nil {
+			return err
+		}
+		if len(tx.Receiver) == 0 {
+			return fmt.Errorf("missing new owner for name %s", name)
+		}
+		record.Owner = tx.Receiver
+	}
+
+	return v.putJSON(nameKey(name), record)
+}
+
+func checkNameOwner(record *NameRecord, name string, sender []byte, height int) error {
+	if record == nil || record.Expired(height) {
+		return fmt.Errorf("name %s is not registered", name)
+	}
+	if string(record.Owner) != string(sender) {
+		return fmt.Errorf("name %s is owned by %x", name, record.Owner)
+	}
+	return nil
+}
+
+func (v *stateView) applyAnchor(tx *Transaction) error {
+	data, err := tx.anchorData()
+	if err != nil {
+		return err
+	}
+
+	// The first anchor of a hash is the one that proves its earliest existence
+	key := anchorKey(data.Hash)
+	if _, exists := v.get(key); exists {
+		return fmt.Errorf("hash %x is already anchored", data.Hash)
+	}
+
+	return v.putJSON(key, AnchorRecord{
+		Hash:    data.Hash,
+		Height:  v.height,
+		TxIndex: v.txIndex,
+	})
+}
+
+func (v *stateView) allowance(owner, spender []byte, asset string) (float64, error) {
+	var amount float64
+	if _, err := v.getJSON(allowanceKey(owner, spender, asset), &amount); err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+func (v *stateView) applyApprove(tx *Transaction) error {
+	if len(tx.Receiver) == 0 {
+		return fmt.Errorf("missing spender")
+	}
+	if string(tx.Receiver) == string(tx.Sender) {
+		return fmt.Errorf("cannot approve yourself as spender")
+	}
+	if err := v.checkAsset(tx.Asset); err != nil {
+		return err
+	}
+
+	if err := v.indexAllowance(tx.Sender, tx.Receiver, tx.Asset, tx.Amount); err != nil {
+		return err
+	}
+	return v.putJSON(allowanceKey(tx.Sender, tx.Receiver, tx.Asset), tx.Amount)
+}
+
+// indexAllowance keeps the owner's allowance index, used to list them, to
+// one entry per (spender, asset) pair with a non-zero allowance
+func (v *stateView) indexAllowance(owner, spender []byte, asset string, amount float64) error {
+	var grants []*Allowance
+	if _, err := v.getJSON(allowanceIndexKey(owner), &grants); err != nil {
+		return err
+	}
+
+	indexed := false
+	kept := grants[:0]
+	for _, grant := range grants {
+		if string(grant.Spender) == string(spender) && grant.Asset == asset {
+			indexed = true
+			continue
+		}
+		kept = append(kept, grant)
+	}
+	if indexed == (amount > 0) {
+		return nil // Already listed, or never was
+	}
+	if amount > 0 {
+		kept = append(kept, &Allowance{Owner: owner, Spender: spender, Asset: asset})
+	}
+	return v.putJSON(allowanceIndexKey(owner), kept)
+}
+
+func (v *stateView) applyTransferFrom(tx *Transaction) error {
+	var op TransferFrom
+	if err := json.Unmarshal(tx.Data, &op); err != nil {
+		return fmt.Errorf("invalid transfer-from payload: %w", err)
+	}
+	if len(op.Owner) == 0 {
+		return fmt.Errorf("missing owner")
+	}
+	if err := v.checkAsset(tx.Asset); err != nil {
+		return err
+	}
+
+	allowed, err := v.allowance(op.Owner, tx.Sender, tx.Asset)
+	if err != nil {
+		return err
+	}
+	if allowed < tx.Amount {
+		return fmt.Errorf("allowance of %x from %x is %f, need %f", tx.Sender, op.Owner, allowed, tx.Amount)
+	}
+	if err := v.putJSON(allowanceKey(op.Owner, tx.Sender, tx.Asset), allowed-tx.Amount); err != nil {
+		return err
+	}
+	if err := v.indexAllowance(op.Owner, tx.Sender, tx.Asset, allowed-tx.Amount); err != nil {
+		return err
+	}
+
+	if err := v.debit(op.Owner, tx.Asset, tx.Amount); err != nil {
+		return err
+	}
+	return v.credit(tx.Receiver, tx.Asset, tx.Amount)
+}
+
+func (v *stateView) vestingSchedules(address []byte) ([]*VestingSchedule, error) {
+	var schedules []*VestingSchedule
+	if _, err := v.getJSON(vestingKey(address), &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// locked returns the native coins of an address still locked at the view height
+func (v *stateView) locked(address []byte) (float64, error) {
+	schedules, err := v.vestingSchedules(address)
+	if err != nil {
+		return 0, err
+	}
+	var locked float64
+	for _, schedule := range schedules {
+		locked += schedule.Locked(v.height)
+	}
+	return locked, nil
+}
+
+// applyVestingGrant credits the receiver and locks the amount under the
+// grant's schedule. Funding the grant is left to the caller.
+func (v *stateView) applyVestingGrant(tx *Transaction) error {
+	if tx.Asset != NativeAsset {
+		return fmt.Errorf("vesting grants can only lock %s", NativeAssetSymbol)
+	}
+	schedule, err := tx.vestingSchedule()
+	if err != nil {
+		return err
+	}
+
+	if err := v.credit(tx.Receiver, NativeAsset, tx.Amount); err != nil {
+		return err
+	}
+
+	schedules, err := v.vestingSchedules(tx.Receiver)
+	if err != nil {
+		return err
+	}
+	// Fully vested schedules lock nothing any more; drop them before counting
+	active := schedules[:0]
+	for _, s := range schedules {
+		if s.End > v.height {
+			active = append(active, s)
+		}
+	}
+	if len(active) >= MaxVestingSchedules {
+		return fmt.Errorf("%x already has %d active vesting schedules", tx.Receiver, MaxVestingSchedules)
+	}
+	return v.putJSON(vestingKey(tx.Receiver), append(active, schedule))
+}
+
+// assetLabel returns a printable name for an asset ID
+func assetLabel(asset string) string {
+	if asset == NativeAsset {
+		return NativeAssetSymbol
+	}
+	return asset
+}
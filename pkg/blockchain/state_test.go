@@ -0,0 +1,241 @@
+package blockchain
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+const testChainID = "test-chain"
+
+// testStorage keeps state keys in a map. Only Get and Put are used by the
+// state; the block methods of Storage are left unimplemented.
+type testStorage struct {
+	Storage
+	data map[string][]byte
+}
+
+func (s *testStorage) Get(key string) ([]byte, error) {
+	value, ok := s.data[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return value, nil
+}
+
+func (s *testStorage) Put(key string, value []byte) error {
+	s.data[key] = value
+	return nil
+}
+
+// testAccount signs transactions with the next nonce of its address
+type testAccount struct {
+	address []byte
+	key     ed25519.PrivateKey
+	nonce   uint64
+}
+
+func newTestAccount(t *testing.T) *testAccount {
+	t.Helper()
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testAccount{address: SchemeAddress(SchemeEd25519, pub), key: key}
+}
+
+// sign makes the account the sender of tx and signs it
+func (a *testAccount) sign(t *testing.T, tx *Transaction) *Transaction {
+	t.Helper()
+	a.nonce++
+	tx.Sender = a.address
+	tx.Nonce = a.nonce
+	tx.ChainID = testChainID
+	tx.Scheme = SchemeEd25519
+	tx.PublicKey = a.key.Public().(ed25519.PublicKey)
+	hash, err := tx.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Signature = ed25519.Sign(a.key, hash)
+	return tx
+}
+
+// stateTest is a state with two accounts holding 100 native coins each
+type stateTest struct {
+	storage    *testStorage
+	state      *State
+	alice, bob *testAccount
+}
+
+func newStateTest(t *testing.T) *stateTest {
+	t.Helper()
+	storage := &testStorage{data: map[string][]byte{chainIDKey: []byte(testChainID)}}
+	s := &stateTest{storage: storage, state: NewState(storage), alice: newTestAccount(t), bob: newTestAccount(t)}
+	for _, account := range []*testAccount{s.alice, s.bob} {
+		if err := s.applyBlock(&Block{Transactions: []*Transaction{
+			{Sender: GenesisSender, Receiver: account.address, Amount: 100},
+		}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+// applyBlock applies a block and, if it is valid, commits its changes
+func (s *stateTest) applyBlock(block *Block) error {
+	view := s.state.newView(block.Index)
+	if err := view.applyBlock(block); err != nil {
+		return err
+	}
+	for _, change := range view.changes() {
+		s.storage.data[change.Key] = change.Value
+	}
+	return nil
+}
+
+// apply applies transactions in a block at the given height
+func (s *stateTest) apply(height int, txs ...*Transaction) error {
+	return s.applyBlock(&Block{Index: height, Transactions: txs})
+}
+
+// must applies transactions that set up a test case
+func (s *stateTest) must(t *testing.T, height int, txs ...*Transaction) {
+	t.Helper()
+	if err := s.apply(height, txs...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// wantBalance checks the balance of an address for an asset
+func (s *stateTest) wantBalance(t *testing.T, address []byte, asset string, want float64) {
+	t.Helper()
+	got, err := s.state.Balance(address, asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("%s balance of %x = %f, want %f", assetLabel(asset), address, got, want)
+	}
+}
+
+func TestApplyBlockIsAllOrNothing(t *testing.T) {
+	s := newStateTest(t)
+	err := s.apply(1,
+		s.alice.sign(t, &Transaction{Receiver: s.bob.address, Amount: 10}),
+		s.alice.sign(t, &Transaction{Receiver: s.bob.address, Amount: 1000}),
+	)
+	if err == nil {
+		t.Fatal("applyBlock accepted a block with an overspending transaction")
+	}
+	s.wantBalance(t, s.alice.address, NativeAsset, 100)
+	s.wantBalance(t, s.bob.address, NativeAsset, 100)
+}
+
+func TestApplyBlockFees(t *testing.T) {
+	reward := func(s *stateTest, amount float64) *Transaction {
+		return &Transaction{Sender: ConsensusSender, Receiver: s.bob.address, Amount: amount}
+	}
+	payment := func(t *testing.T, s *stateTest, fee float64) *Transaction {
+		return s.alice.sign(t, &Transaction{Receiver: s.bob.address, Amount: 10, Fee: fee})
+	}
+
+	tests := []struct {
+		name      string
+		txs       func(t *testing.T, s *stateTest) []*Transaction
+		ok        bool
+		wantAlice float64
+		wantBob   float64
+	}{
+		{
+			name:      "reward without fees",
+			txs:       func(t *testing.T, s *stateTest) []*Transaction { return []*Transaction{reward(s, BlockReward)} },
+			ok:        true,
+			wantAlice: 100,
+			wantBob:   100 + BlockReward,
+		},
+		{
+			name: "proposer claims the fees",
+			txs: func(t *testing.T, s *stateTest) []*Transaction {
+				return []*Transaction{payment(t, s, 2), reward(s, BlockReward+2)}
+			},
+			ok:        true,
+			wantAlice: 100 - 10 - 2,
+			wantBob:   100 + 10 + BlockReward + 2,
+		},
+		{
+			name: "reward before the fees it claims",
+			txs: func(t *testing.T, s *stateTest) []*Transaction {
+				return []*Transaction{reward(s, BlockReward+2), payment(t, s, 2)}
+			},
+			ok:        true,
+			wantAlice: 100 - 10 - 2,
+			wantBob:   100 + 10 + BlockReward + 2,
+		},
+		{
+			name: "proposer leaves fees unclaimed",
+			txs: func(t *testing.T, s *stateTest) []*Transaction {
+				return []*Transaction{payment(t, s, 2), reward(s, BlockReward)}
+			},
+			ok:        true,
+			wantAlice: 100 - 10 - 2,
+			wantBob:   100 + 10 + BlockReward,
+		},
+		{
+			name: "mint above reward plus fees",
+			txs: func(t *testing.T, s *stateTest) []*Transaction {
+				return []*Transaction{payment(t, s, 2), reward(s, BlockReward+2.5)}
+			},
+		},
+		{
+			name: "two rewards above the cap",
+			txs: func(t *testing.T, s *stateTest) []*Transaction {
+				return []*Transaction{reward(s, BlockReward), reward(s, BlockReward)}
+			},
+		},
+		{
+			name: "fee above the balance",
+			txs:  func(t *testing.T, s *stateTest) []*Transaction { return []*Transaction{payment(t, s, 95)} },
+		},
+		{
+			name: "negative fee",
+			txs:  func(t *testing.T, s *stateTest) []*Transaction { return []*Transaction{payment(t, s, -1)} },
+		},
+		{
+			name: "coinbase paying a fee",
+			txs: func(t *testing.T, s *stateTest) []*Transaction {
+				tx := reward(s, BlockReward)
+				tx.Fee = 1
+				return []*Transaction{tx}
+			},
+		},
+		{
+			name: "coinbase minting an asset",
+			txs: func(t *testing.T, s *stateTest) []*Transaction {
+				tx := reward(s, BlockReward)
+				tx.Asset = "0011223344556677"
+				return []*Transaction{tx}
+			},
+		},
+		{
+			name: "genesis allocation after genesis",
+			txs: func(t *testing.T, s *stateTest) []*Transaction {
+				return []*Transaction{{Sender: GenesisSender, Receiver: s.bob.address, Amount: BlockReward}}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStateTest(t)
+			if err := s.apply(1, tt.txs(t, s)...); (err == nil) != tt.ok {
+				t.Fatalf("applyBlock() = %v, want ok=%t", err, tt.ok)
+			}
+			if !tt.ok {
+				return
+			}
+			s.wantBalance(t, s.alice.address, NativeAsset, tt.wantAlice)
+			s.wantBalance(t, s.bob.address, NativeAsset, tt.wantBob)
+		})
+	}
+}
@@ -6,17 +6,82 @@ import (
 	"fmt"
 )
 
+// TxType identifies how the state machine interprets a transaction.
+// The zero value is a plain value transfer so that transactions created
+// before typed transactions existed keep their meaning (and their hash).
+type TxType string
+
+const (
+	TxTransfer   TxType = ""            // Move Amount of Asset from Sender to Receiver
+	TxIssueAsset TxType = "issue_asset" // Create a new asset, crediting its supply to Sender
+
+	TxNameRegister TxType = "name_register" // Claim an unowned or expired name for Sender
+	TxNameRenew    TxType = "name_renew"    // Extend Sender's name for another period
+	TxNameTransfer TxType = "name_transfer" // Hand Sender's name over to Receiver
+
+	TxAnchor TxType = "anchor" // Timestamp a document hash (and optionally the document)
+
+	TxApprove      TxType = "approve"       // Allow Receiver to spend up to Amount of Sender's Asset
+	TxTransferFrom TxType = "transfer_from" // Spend an approved allowance on the owner's behalf
+
+	TxVestingGrant TxType = "vesting_grant" // Give Receiver Amount native coins that unlock over time
+
+	TxRotateKey TxType = "rotate_key" // Let a new key sign for Sender instead of the current one
+)
+
+// AddressLength is the size in bytes of an account address
+const AddressLength = 20
+
+// BlockReward is the amount of native coin minted for the proposer of each
+// block, on top of the fees it collects
+const BlockReward = 1.0
+
+// System senders mint native coins instead of spending from a balance
+var (
+	GenesisSender   = []byte("genesis")
+	ConsensusSender = []byte("consensus")
+)
+
 type Transaction struct {
 	Sender    []byte
 	Receiver  []byte
 	Amount    float64
 	Timestamp int64
 	Signature []byte
+
+	// Typed transaction fields. They are omitted from the JSON encoding when
+	// empty so legacy transactions hash exactly as they did before.
+	Type  TxType `json:",omitempty"`
+	Asset string `json:",omitempty"` // Asset ID, empty for the native coin
+	Data  []byte `json:",omitempty"` // Type-specific JSON payload
+
+	Fee float64 `json:",omitempty"` // Native coin paid to the block proposer
+
+	PublicKey []byte          `json:",omitempty"` // Sender's public key, covered by the signature
+	Scheme    SignatureScheme `json:",omitempty"` // Scheme of PublicKey and Signature, empty for P-256
+
+	// Replay protection. A transaction is only valid as the sender's next
+	// one, on the chain it names. Legacy blocks may hold transactions
+	// without either (see Genesis.ReplayHeight).
+	Nonce   uint64 `json:",omitempty"`
+	ChainID string `json:",omitempty"`
+
+	// Memo encrypted to the receiver's public key. Nodes only see and check
+	// its size; see wallet.EncryptMemo.
+	Memo []byte `json:",omitempty"`
 }
 
 func (t *Transaction) Hash() ([]byte, error) {
 	txCopy := *t
 	txCopy.Signature = nil
+	// Hex-encoded addresses decode to empty (not nil) slices on the wire,
+	// so treat both the same way to keep hashes stable across nodes
+	if txCopy.Sender == nil {
+		txCopy.Sender = []byte{}
+	}
+	if txCopy.Receiver == nil {
+		txCopy.Receiver = []byte{}
+	}
 	data, err := json.Marshal(txCopy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal transaction: %w", err)
@@ -24,3 +89,112 @@ func (t *Transaction) Hash() ([]byte, error) {
 	hash := sha256.Sum256(data)
 	return hash[:], nil
 }
+
+// Validate performs the stateless checks every node applies to a transaction
+func (t *Transaction) Validate() error {
+	switch t.Type {
+	case TxTransfer, TxIssueAsset, TxTransferFrom, TxVestingGrant:
+		if t.Amount <= 0 {
+			return fmt.Errorf("invalid transaction amount %f", t.Amount)
+		}
+	case TxNameRegister, TxNameRenew, TxNameTransfer, TxAnchor:
+		if t.Amount != 0 {
+			return fmt.Errorf("%s transactions must not carry an amount", t.Type)
+		}
+	case TxRotateKey:
+		if t.Amount != 0 {
+			return fmt.Errorf("%s transactions must not carry an amount", t.Type)
+		}
+		if _, err := t.keyRotation(); err != nil {
+			return err
+		}
+	case TxApprove:
+		if t.Amount < 0 {
+			return fmt.Errorf("invalid allowance %f", t.Amount)
+		}
+	default:
+		return fmt.Errorf("unknown transaction type %q", t.Type)
+	}
+
+	if t.Fee < 0 {
+		return fmt.Errorf("invalid transaction fee %f", t.Fee)
+	}
+	if t.IsCoinbase() && t.Fee != 0 {
+		return fmt.Errorf("coinbase transactions cannot pay a fee")
+	}
+	if err := t.checkMemo(); err != nil {
+		return err
+	}
+	return t.VerifySignature()
+}
+
+// Size returns the encoded size of the transaction in bytes
+func (t *Transaction) Size() int {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// FeeRate returns the fee paid per encoded byte
+func (t *Transaction) FeeRate() float64 {
+	size := t.Size()
+	if size == 0 {
+		return 0
+	}
+	return t.Fee / float64(size)
+}
+
+// IsCoinbase reports whether the transaction mints new native coins
+// (genesis allocations and block rewards)
+func (t *Transaction) IsCoinbase() bool {
+	return string(t.Sender) == string(GenesisSender) || string(t.Sender) == string(ConsensusSender)
+}
+
+// BalanceChange is the effect of a transaction on one balance
+type BalanceChange struct {
+	Address []byte
+	Asset   string
+	Amount  float64 // Positive for credits, negative for debits
+}
+
+// BalanceChanges lists the balance changes the state makes when it applies
+// the transaction: the fee, the amount moved and any supply issued. It
+// assumes the transaction is valid, e.g. because it is in a committed block.
+func (t *Transaction) BalanceChanges() ([]BalanceChange, error) {
+	if t.IsCoinbase() {
+		return []BalanceChange{{t.Receiver, NativeAsset, t.Amount}}, nil
+	}
+
+	var changes []BalanceChange
+	if t.Fee > 0 {
+		changes = append(changes, BalanceChange{t.Sender, NativeAsset, -t.Fee})
+	}
+
+	switch t.Type {
+	case TxTransfer:
+		changes = append(changes,
+			BalanceChange{t.Sender, t.Asset, -t.Amount},
+			BalanceChange{t.Receiver, t.Asset, t.Amount})
+	case TxIssueAsset:
+		id, err := AssetID(t)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, BalanceChange{t.Sender, id, t.Amount})
+	case TxTransferFrom:
+		var op TransferFrom
+		if err := json.Unmarshal(t.Data, &op); err != nil {
+			return nil, fmt.Errorf("invalid transfer-from payload: %w", err)
+		}
+		changes = append(changes,
+			BalanceChange{op.Owner, t.Asset, -t.Amount},
+			BalanceChange{t.Receiver, t.Asset, t.Amount})
+	case TxVestingGrant:
+		changes = append(changes,
+			BalanceChange{t.Sender, NativeAsset, -t.Amount},
+			BalanceChange{t.Receiver, NativeAsset, t.Amount})
+	}
+	return changes, nil
+}
@@ -0,0 +1,113 @@
+package blockchain
+
+import "testing"
+
+// grant has the account lock amount for the receiver until block end, when
+// all of it unlocks
+func grant(t *testing.T, grantor *testAccount, receiver []byte, amount float64, end int) *Transaction {
+	t.Helper()
+	tx, err := NewVestingGrantTransaction(nil, receiver, amount, 0, end, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return grantor.sign(t, tx)
+}
+
+// fillSchedules has alice, funded for it, give bob every schedule slot
+func fillSchedules(t *testing.T, s *stateTest, end int) {
+	t.Helper()
+	s.must(t, 0, &Transaction{Sender: GenesisSender, Receiver: s.alice.address, Amount: MaxVestingSchedules * MinVestingGrant})
+	var grants []*Transaction
+	for i := 0; i < MaxVestingSchedules; i++ {
+		grants = append(grants, grant(t, s.alice, s.bob.address, MinVestingGrant, end))
+	}
+	s.must(t, 1, grants...)
+}
+
+func TestApplyVestingGrant(t *testing.T) {
+	tests := []struct {
+		name  string
+		run   func(t *testing.T, s *stateTest) error
+		ok    bool
+		check func(t *testing.T, s *stateTest)
+	}{
+		{
+			name: "grant",
+			run: func(t *testing.T, s *stateTest) error {
+				return s.apply(1, grant(t, s.alice, s.bob.address, 50, 1000))
+			},
+			ok: true,
+			check: func(t *testing.T, s *stateTest) {
+				s.wantBalance(t, s.alice.address, NativeAsset, 50)
+				status, err := s.state.Vesting(s.bob.address, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if status.Balance != 150 || status.Spendable() != 100 {
+					t.Errorf("balance %f spendable %f, want 150 and 100", status.Balance, status.Spendable())
+				}
+			},
+		},
+		{
+			name: "spend locked coins",
+			run: func(t *testing.T, s *stateTest) error {
+				s.must(t, 1, grant(t, s.alice, s.bob.address, 50, 1000))
+				return s.apply(2, s.bob.sign(t, &Transaction{Receiver: s.alice.address, Amount: 101}))
+			},
+		},
+		{
+			// Dust grants would let anyone fill an account's schedules for free
+			name: "dust grant",
+			run: func(t *testing.T, s *stateTest) error {
+				tx := &Transaction{Receiver: s.bob.address, Amount: 0.0001, Type: TxVestingGrant, Data: []byte(`{"start":0,"cliff":0,"end":1000000}`)}
+				return s.apply(1, s.alice.sign(t, tx))
+			},
+		},
+		{
+			name: "grant once the schedules are full",
+			run: func(t *testing.T, s *stateTest) error {
+				fillSchedules(t, s, 1000)
+				return s.apply(2, grant(t, s.bob, s.alice.address, MinVestingGrant, 1000))
+			},
+			ok: true, // Only the receiver's schedules are capped
+		},
+		{
+			name: "grant to an account with full schedules",
+			run: func(t *testing.T, s *stateTest) error {
+				fillSchedules(t, s, 1000)
+				return s.apply(2, grant(t, s.alice, s.bob.address, MinVestingGrant, 1000))
+			},
+		},
+		{
+			name: "filling the schedules costs the griefer",
+			run: func(t *testing.T, s *stateTest) error {
+				fillSchedules(t, s, 1000)
+				return nil
+			},
+			ok: true,
+			check: func(t *testing.T, s *stateTest) {
+				s.wantBalance(t, s.alice.address, NativeAsset, 100)
+				s.wantBalance(t, s.bob.address, NativeAsset, 100+MaxVestingSchedules*MinVestingGrant)
+			},
+		},
+		{
+			name: "fully vested schedules free their slots",
+			run: func(t *testing.T, s *stateTest) error {
+				fillSchedules(t, s, 5)
+				return s.apply(5, grant(t, s.alice, s.bob.address, MinVestingGrant, 1000))
+			},
+			ok: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStateTest(t)
+			if err := tt.run(t, s); (err == nil) != tt.ok {
+				t.Fatalf("apply = %v, want ok=%t", err, tt.ok)
+			}
+			if tt.check != nil {
+				tt.check(t, s)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@
 package consensus
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/protoconv"
 	"github.com/nguyentrinhquy1411/blockchain-go/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -33,30 +35,67 @@ type ConsensusEngine struct {
 	mutex    sync.RWMutex   // Protects the votes map from race conditions
 
 	// Blockchain components
-	blockchain *blockchain.Blockchain // Reference to the blockchain
+	blockchain *blockchain.Blockchain       // Reference to the blockchain
+	txPool     *blockchain.TxPool           // Pending transactions to include in blocks
+	proposals  map[string]*blockchain.Block // Maps block hash to the proposed block awaiting votes
+	proposed   *blockchain.Block            // Last block this node proposed, proposed again until the tip moves
+
+	rewardAddress []byte // Receives the block reward and fees of blocks this node proposes
+
+	// Proposal and vote signing
+	signer     Signer                     // Signs this node's proposals and votes; nil sends them unsigned
+	validators map[string]bool            // Addresses allowed to propose and vote; empty accepts unsigned messages
+	voters     map[string]map[string]bool // Maps block hash to the signed voters already counted
 
 	// Consensus parameters
 	majorityThreshold int           // Minimum votes needed for consensus (2/3 majority)
 	blockProposalTime time.Duration // Time interval between block proposals
 	voteTimeout       time.Duration // Maximum time to wait for votes
+	maxBlockTxs       int           // Maximum number of transactions per block
 }
 
 // NewConsensusEngine creates a new consensus engine
 // Parameters:
 //   - nodeID: unique identifier for this node
-//   - blockchain: reference to the blockchain instance
+//   - bc: reference to the blockchain instance
+//   - txPool: pool of pending transactions the leader draws from
 //   - peers: list of peer node addresses
 //   - isLeader: whether this node should act as leader
-func NewConsensusEngine(nodeID string, blockchain *blockchain.Blockchain, peers []string, isLeader bool) *ConsensusEngine {
+func NewConsensusEngine(nodeID string, bc *blockchain.Blockchain, txPool *blockchain.TxPool, peers []string, isLeader bool) *ConsensusEngine {
 	return &ConsensusEngine{
 		nodeID:            nodeID,
 		isLeader:          isLeader,
 		peers:             peers,
 		votes:             make(map[string]int),
-		blockchain:        blockchain,
+		blockchain:        bc,
+		txPool:            txPool,
+		proposals:         make(map[string]*blockchain.Block),
+		voters:            make(map[string]map[string]bool),
+		rewardAddress:     []byte("reward"),
 		majorityThreshold: calculateMajority(len(peers) + 1), // +1 for this node
 		blockProposalTime: 10 * time.Second,
 		voteTimeout:       5 * time.Second,
+		maxBlockTxs:       100,
+	}
+}
+
+// SetRewardAddress sets the address credited with the reward and fees of
+// the blocks this node proposes
+func (ce *ConsensusEngine) SetRewardAddress(address []byte) {
+	ce.rewardAddress = address
+}
+
+// SetSigner makes the node sign its block proposals and votes
+func (ce *ConsensusEngine) SetSigner(signer Signer) {
+	ce.signer = signer
+}
+
+// SetValidators restricts proposals and votes to messages signed by one of
+// the given addresses
+func (ce *ConsensusEngine) SetValidators(addresses [][]byte) {
+	ce.validators = make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		ce.validators[string(address)] = true
 	}
 }
 
@@ -121,50 +160,69 @@ func (ce *ConsensusEngine) proposeNewBlock() {
 
 	log.Printf("[%s] CONSENSUS: Proposing new block...", ce.nodeID)
 
-	// Step 1: Create a new block with consensus transaction
-	// In a real blockchain, this would include pending transactions from mempool
-	transactions := []*blockchain.Transaction{
-		{
-			Sender:    []byte("consensus"), // System transaction
-			Receiver:  []byte("reward"),    // Block reward
-			Amount:    1.0,                 // Fixed reward amount
-			Timestamp: time.Now().Unix(),   // Current timestamp
-		},
-	}
-
-	// Step 2: Get the latest block to build upon
+	// Step 1: Get the latest block to build upon
 	latestBlock := ce.blockchain.GetLatestBlock()
 
-	// Step 3: Create new block using blockchain constructor
-	// This automatically calculates merkle root, hash, etc.
-	newBlock := blockchain.NewBlock(
-		latestBlock.Index+1,          // Next block index
-		transactions,                 // Block transactions
-		latestBlock.CurrentBlockHash, // Previous block hash
-	)
+	// Step 2: Build the next block. A proposal that timed out is proposed
+	// again unchanged, since a signer never signs two blocks at one height.
+	newBlock := ce.proposed
+	if newBlock == nil || newBlock.Index != latestBlock.Index+1 ||
+		!bytes.Equal(newBlock.PreviousBlockHash, latestBlock.CurrentBlockHash) {
+		newBlock = ce.buildBlock(latestBlock)
+		ce.proposed = newBlock
+	}
 
-	// Step 4: Calculate block hash for voting
+	// Step 3: Calculate block hash for voting
 	blockHash := fmt.Sprintf("%x", newBlock.CurrentBlockHash)
 
 	log.Printf("[%s] CONSENSUS: Created block %d with hash %s",
 		ce.nodeID, newBlock.Index, blockHash[:8])
 
-	// Step 5: Initialize voting for this block
+	// Step 4: Initialize voting for this block
 	// Leader automatically votes for their own proposal
 	ce.mutex.Lock()
 	ce.votes[blockHash] = 1 // Leader's automatic vote
+	ce.proposals[blockHash] = newBlock
 	ce.mutex.Unlock()
 
 	log.Printf("[%s] CONSENSUS: Leader vote recorded for block %s",
 		ce.nodeID, blockHash[:8])
 
-	// Step 6: Send block proposal to all peer nodes
+	// Step 5: Send block proposal to all peer nodes
 	ce.broadcastBlockProposal(newBlock)
 
-	// Step 7: Wait for votes and check consensus
+	// Step 6: Wait for votes and check consensus
 	go ce.waitForConsensus(blockHash, newBlock)
 }
 
+// buildBlock creates the next block on top of latestBlock
+func (ce *ConsensusEngine) buildBlock(latestBlock *blockchain.Block) *blockchain.Block {
+	// Pick the pending transactions with the best fee rate that still
+	// apply to the current state, leaving room for the consensus transaction
+	transactions := ce.blockchain.SelectTransactions(ce.txPool.PendingByFeeRate(), ce.maxBlockTxs-1)
+
+	var fees float64
+	for _, tx := range transactions {
+		fees += tx.Fee
+	}
+
+	// The consensus transaction pays the block reward plus collected fees to this node
+	reward := &blockchain.Transaction{
+		Sender:    blockchain.ConsensusSender,    // System transaction
+		Receiver:  ce.rewardAddress,              // Block proposer
+		Amount:    blockchain.BlockReward + fees, // Fixed reward plus fees
+		Timestamp: time.Now().Unix(),             // Current timestamp
+	}
+	transactions = append([]*blockchain.Transaction{reward}, transactions...)
+
+	// This automatically calculates merkle root, hash, etc.
+	return blockchain.NewBlock(
+		latestBlock.Index+1,          // Next block index
+		transactions,                 // Block transactions
+		latestBlock.CurrentBlockHash, // Previous block hash
+	)
+}
+
 // broadcastBlockProposal sends a block proposal to all peer nodes
 func (ce *ConsensusEngine) broadcastBlockProposal(block *blockchain.Block) {
 	blockHash := fmt.Sprintf("%x", block.CurrentBlockHash)
@@ -172,13 +230,26 @@ func (ce *ConsensusEngine) broadcastBlockProposal(block *blockchain.Block) {
 		ce.nodeID, blockHash[:8], len(ce.peers))
 
 	// Convert internal block to protobuf format for network transmission
-	protoBlock := ce.blockToProto(block)
+	protoBlock := protoconv.BlockToProto(block)
+
+	// Sign the proposal once for all peers
+	var signature *proto.NodeSignature
+	if ce.signer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), ce.voteTimeout)
+		sig, err := ce.signer.SignProposal(ctx, block.Index, blockHash)
+		cancel()
+		if err != nil {
+			log.Printf("[%s] CONSENSUS: Failed to sign proposal %s: %v", ce.nodeID, blockHash[:8], err)
+			return
+		}
+		signature = sig
+	}
 
 	// Send proposal to each peer concurrently
 	successCount := 0
 	for _, peerAddr := range ce.peers {
 		go func(peer string) {
-			success := ce.sendBlockProposal(peer, protoBlock)
+			success := ce.sendBlockProposal(peer, protoBlock, signature)
 			if success {
 				successCount++
 			}
@@ -190,7 +261,7 @@ func (ce *ConsensusEngine) broadcastBlockProposal(block *blockchain.Block) {
 }
 
 // sendBlockProposal sends a block proposal to a specific peer
-func (ce *ConsensusEngine) sendBlockProposal(peerAddr string, protoBlock *proto.Block) bool {
+func (ce *ConsensusEngine) sendBlockProposal(peerAddr string, protoBlock *proto.Block, signature *proto.NodeSignature) bool {
 	// Step 1: Establish gRPC connection to peer
 	conn, err := grpc.NewClient(peerAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -210,6 +281,7 @@ func (ce *ConsensusEngine) sendBlockProposal(peerAddr string, protoBlock *proto.
 	resp, err := client.ProposeBlock(ctx, &proto.ProposeBlockRequest{
 		Block:      protoBlock,
 		ProposerId: ce.nodeID,
+		Signature:  signature,
 	})
 
 	if err != nil {
@@ -224,13 +296,22 @@ func (ce *ConsensusEngine) sendBlockProposal(peerAddr string, protoBlock *proto.
 
 // ProcessBlockProposal processes an incoming block proposal from another node
 // This is called when a follower receives a proposal from the leader
-func (ce *ConsensusEngine) ProcessBlockProposal(proposerID string, protoBlock *proto.Block) (bool, string) {
+func (ce *ConsensusEngine) ProcessBlockProposal(proposerID string, protoBlock *proto.Block, signature *proto.NodeSignature) (bool, string) {
 	blockHash := protoBlock.Hash
 	log.Printf("[%s] CONSENSUS: Processing block proposal from %s, hash %s",
 		ce.nodeID, proposerID, blockHash[:8])
 
+	if _, err := ce.checkSigner(signature, ProposalDigest(int(protoBlock.Height), blockHash)); err != nil {
+		log.Printf("[%s] CONSENSUS: Rejected proposal %s: %v", ce.nodeID, blockHash[:8], err)
+		return false, fmt.Sprintf("Invalid proposal signature: %v", err)
+	}
+
 	// Step 1: Convert protobuf block to internal format
-	block := ce.protoToBlock(protoBlock)
+	block, err := protoconv.BlockFromProto(protoBlock)
+	if err != nil {
+		log.Printf("[%s] CONSENSUS: Rejected proposal %s: %v", ce.nodeID, blockHash[:8], err)
+		return false, fmt.Sprintf("Invalid block: %v", err)
+	}
 
 	// Step 2: Validate the proposed block
 	if !ce.validateProposedBlock(block) {
@@ -264,15 +345,25 @@ func (ce *ConsensusEngine) sendVoteToLeader(blockHash string, voteType VoteType)
 	}
 	defer conn.Close()
 
-	// Step 3: Create client and send vote
+	// Step 3: Create client and send the (signed) vote
 	client := proto.NewBlockchainServiceClient(conn)
 	ctx, cancel := context.WithTimeout(context.Background(), ce.voteTimeout)
 	defer cancel()
 
+	approve := voteType == VoteApprove
+	var signature *proto.NodeSignature
+	if ce.signer != nil {
+		if signature, err = ce.signer.SignVote(ctx, blockHash, ce.nodeID, approve); err != nil {
+			log.Printf("[%s] CONSENSUS: Failed to sign vote for %s: %v", ce.nodeID, blockHash[:8], err)
+			return
+		}
+	}
+
 	_, err = client.Vote(ctx, &proto.VoteRequest{
 		BlockHash: blockHash,
 		VoterId:   ce.nodeID,
-		Approve:   voteType == VoteApprove,
+		Approve:   approve,
+		Signature: signature,
 	})
 
 	if err != nil {
@@ -286,7 +377,7 @@ func (ce *ConsensusEngine) sendVoteToLeader(blockHash string, voteType VoteType)
 
 // ProcessVote processes an incoming vote from another node
 // This is typically called on the leader node
-func (ce *ConsensusEngine) ProcessVote(voterID, blockHash string, approve bool) (bool, string) {
+func (ce *ConsensusEngine) ProcessVote(voterID, blockHash string, approve bool, signature *proto.NodeSignature) (bool, string) {
 	log.Printf("[%s] CONSENSUS: Processing vote from %s for block %s: %v",
 		ce.nodeID, voterID, blockHash[:8], approve)
 
@@ -294,13 +385,29 @@ func (ce *ConsensusEngine) ProcessVote(voterID, blockHash string, approve bool)
 		return false, "Only leader can process votes"
 	}
 
+	voter, err := ce.checkSigner(signature, VoteDigest(blockHash, voterID, approve))
+	if err != nil {
+		log.Printf("[%s] CONSENSUS: Rejected vote from %s: %v", ce.nodeID, voterID, err)
+		return false, fmt.Sprintf("Invalid vote signature: %v", err)
+	}
+
 	if !approve {
 		log.Printf("[%s] CONSENSUS: Received rejection vote from %s", ce.nodeID, voterID)
 		return true, "Vote recorded (rejected)"
 	}
 
-	// Step 1: Record the approval vote
+	// Step 1: Record the approval vote, counting each signing key once
 	ce.mutex.Lock()
+	if voter != nil {
+		if ce.voters[blockHash][string(voter)] {
+			ce.mutex.Unlock()
+			return true, "Vote already recorded"
+		}
+		if ce.voters[blockHash] == nil {
+			ce.voters[blockHash] = make(map[string]bool)
+		}
+		ce.voters[blockHash][string(voter)] = true
+	}
 	ce.votes[blockHash]++
 	voteCount := ce.votes[blockHash]
 	ce.mutex.Unlock()
@@ -336,6 +443,8 @@ func (ce *ConsensusEngine) waitForConsensus(blockHash string, block *blockchain.
 		// Clean up failed proposal
 		ce.mutex.Lock()
 		delete(ce.votes, blockHash)
+		delete(ce.voters, blockHash)
+		delete(ce.proposals, blockHash)
 		ce.mutex.Unlock()
 	}
 }
@@ -344,22 +453,17 @@ func (ce *ConsensusEngine) waitForConsensus(blockHash string, block *blockchain.
 func (ce *ConsensusEngine) commitBlock(blockHash string) {
 	log.Printf("[%s] CONSENSUS: Committing block %s to blockchain", ce.nodeID, blockHash[:8])
 
-	// Step 1: Create a consensus block for commitment
-	// In a real implementation, we would find the actual proposed block
-	// For now, we create a new consensus block
-	latestBlock := ce.blockchain.GetLatestBlock()
+	// Step 1: Find the proposed block that achieved consensus
+	ce.mutex.Lock()
+	newBlock, ok := ce.proposals[blockHash]
+	delete(ce.proposals, blockHash)
+	ce.mutex.Unlock()
 
-	transactions := []*blockchain.Transaction{
-		{
-			Sender:    []byte("consensus"),
-			Receiver:  []byte("reward"),
-			Amount:    1.0,
-			Timestamp: time.Now().Unix(),
-		},
+	if !ok {
+		log.Printf("[%s] CONSENSUS: No pending proposal for block %s", ce.nodeID, blockHash[:8])
+		return
 	}
 
-	newBlock := blockchain.NewBlock(latestBlock.Index+1, transactions, latestBlock.CurrentBlockHash)
-
 	// Step 2: Add block to blockchain
 	if err := ce.blockchain.AddBlock(newBlock); err != nil {
 		log.Printf("[%s] CONSENSUS: Failed to commit block to blockchain: %v", ce.nodeID, err)
@@ -369,10 +473,12 @@ func (ce *ConsensusEngine) commitBlock(blockHash string) {
 	log.Printf("[%s] CONSENSUS: Block %d successfully committed to blockchain",
 		ce.nodeID, newBlock.Index)
 
-	// Step 3: Clean up vote tracking
+	// Step 3: Clean up vote tracking and drop the included transactions from the pool
 	ce.mutex.Lock()
 	delete(ce.votes, blockHash)
+	delete(ce.voters, blockHash)
 	ce.mutex.Unlock()
+	ce.txPool.Remove(newBlock.Transactions)
 
 	// Step 4: Notify peers about committed block (in a real implementation)
 	ce.notifyPeersBlockCommitted(newBlock)
@@ -407,72 +513,24 @@ func (ce *ConsensusEngine) validateProposedBlock(block *blockchain.Block) bool {
 
 	// Validate each transaction
 	for _, tx := range block.Transactions {
-		if tx.Amount <= 0 {
+		if err := tx.Validate(); err != nil {
 			return false
 		}
 	}
 
 	// Use existing blockchain validation
-	return block.IsValid()
-}
-
-// Conversion helper functions
-
-func (ce *ConsensusEngine) blockToProto(block *blockchain.Block) *proto.Block {
-	var transactions []*proto.Transaction
-	for _, tx := range block.Transactions {
-		transactions = append(transactions, &proto.Transaction{
-			Sender:    fmt.Sprintf("%x", tx.Sender),
-			Receiver:  fmt.Sprintf("%x", tx.Receiver),
-			Amount:    tx.Amount,
-			Timestamp: tx.Timestamp,
-			Signature: tx.Signature,
-		})
-	}
-
-	return &proto.Block{
-		Height:       int32(block.Index),
-		PreviousHash: fmt.Sprintf("%x", block.PreviousBlockHash),
-		MerkleRoot:   fmt.Sprintf("%x", block.MerkleRoot),
-		Timestamp:    block.Timestamp,
-		Transactions: transactions,
-		Hash:         fmt.Sprintf("%x", block.CurrentBlockHash),
+	if !block.IsValid() {
+		return false
 	}
-}
 
-func (ce *ConsensusEngine) protoToBlock(pb *proto.Block) *blockchain.Block {
-	var transactions []*blockchain.Transaction
-	for _, tx := range pb.Transactions {
-		// Convert hex strings back to bytes
-		sender := make([]byte, len(tx.Sender)/2)
-		receiver := make([]byte, len(tx.Receiver)/2)
-		fmt.Sscanf(tx.Sender, "%x", &sender)
-		fmt.Sscanf(tx.Receiver, "%x", &receiver)
-
-		transactions = append(transactions, &blockchain.Transaction{
-			Sender:    sender,
-			Receiver:  receiver,
-			Amount:    tx.Amount,
-			Timestamp: tx.Timestamp,
-			Signature: tx.Signature,
-		})
-	}
-
-	// Convert hex strings back to bytes
-	previousHash := make([]byte, len(pb.PreviousHash)/2)
-	merkleRoot := make([]byte, len(pb.MerkleRoot)/2)
-	currentHash := make([]byte, len(pb.Hash)/2)
-
-	fmt.Sscanf(pb.PreviousHash, "%x", &previousHash)
-	fmt.Sscanf(pb.MerkleRoot, "%x", &merkleRoot)
-	fmt.Sscanf(pb.Hash, "%x", &currentHash)
-
-	return &blockchain.Block{
-		Index:             int(pb.Height),
-		PreviousBlockHash: previousHash,
-		MerkleRoot:        merkleRoot,
-		Timestamp:         pb.Timestamp,
-		Transactions:      transactions,
-		CurrentBlockHash:  currentHash,
+	// Replay the transactions against our state when the block extends our tip.
+	// Nodes that are behind cannot check balances and rely on recovery sync.
+	if block.Index == ce.blockchain.GetLatestBlock().Index+1 {
+		if err := ce.blockchain.CheckBlock(block); err != nil {
+			log.Printf("[%s] CONSENSUS: Block %d rejected: %v", ce.nodeID, block.Index, err)
+			return false
+		}
 	}
+
+	return true
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/protoconv"
 	"github.com/nguyentrinhquy1411/blockchain-go/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -254,7 +255,11 @@ func (re *RecoveryEngine) processReceivedBlock(protoBlock *proto.Block) bool {
 		re.nodeID, protoBlock.Height, protoBlock.Hash[:8])
 
 	// Step 1: Convert protobuf block to internal format
-	block := re.protoToBlock(protoBlock)
+	block, err := protoconv.BlockFromProto(protoBlock)
+	if err != nil {
+		log.Printf("[%s] RECOVERY: Invalid block %d: %v", re.nodeID, protoBlock.Height, err)
+		return false
+	}
 
 	// Step 2: Validate the block before adding
 	if !re.validateReceivedBlock(block) {
@@ -295,9 +300,9 @@ func (re *RecoveryEngine) validateReceivedBlock(block *blockchain.Block) bool {
 	}
 
 	for i, tx := range block.Transactions {
-		if tx.Amount <= 0 {
-			log.Printf("[%s] RECOVERY: Invalid transaction amount at index %d: %f",
-				re.nodeID, i, tx.Amount)
+		if err := tx.Validate(); err != nil {
+			log.Printf("[%s] RECOVERY: Invalid transaction at index %d: %v",
+				re.nodeID, i, err)
 			return false
 		}
 	}
@@ -366,93 +371,3 @@ func (re *RecoveryEngine) GetRecoveryStatus() map[string]interface{} {
 		"max_retries":     re.maxRetries,
 	}
 }
-
-// Helper function to convert protobuf block to internal block format
-func (re *RecoveryEngine) protoToBlock(pb *proto.Block) *blockchain.Block {
-	var transactions []*blockchain.Transaction
-	for _, tx := range pb.Transactions {
-		// Convert hex strings back to bytes
-		sender := make([]byte, len(tx.Sender)/2)
-		receiver := make([]byte, len(tx.Receiver)/2)
-
-		// Handle potential errors in hex decoding
-		if len(tx.Sender)%2 == 0 && len(tx.Receiver)%2 == 0 {
-			for i := 0; i < len(tx.Sender); i += 2 {
-				if i/2 < len(sender) {
-					sender[i/2] = hexToByte(tx.Sender[i : i+2])
-				}
-			}
-			for i := 0; i < len(tx.Receiver); i += 2 {
-				if i/2 < len(receiver) {
-					receiver[i/2] = hexToByte(tx.Receiver[i : i+2])
-				}
-			}
-		} else {
-			// Fallback: use string bytes if hex decoding fails
-			sender = []byte(tx.Sender)
-			receiver = []byte(tx.Receiver)
-		}
-
-		transactions = append(transactions, &blockchain.Transaction{
-			Sender:    sender,
-			Receiver:  receiver,
-			Amount:    tx.Amount,
-			Timestamp: tx.Timestamp,
-			Signature: tx.Signature,
-		})
-	}
-
-	// Convert hex strings back to bytes for block hashes
-	previousHash := hexToBytes(pb.PreviousHash)
-	merkleRoot := hexToBytes(pb.MerkleRoot)
-	currentHash := hexToBytes(pb.Hash)
-
-	return &blockchain.Block{
-		Index:             int(pb.Height),
-		PreviousBlockHash: previousHash,
-		MerkleRoot:        merkleRoot,
-		Timestamp:         pb.Timestamp,
-		Transactions:      transactions,
-		CurrentBlockHash:  currentHash,
-	}
-}
-
-// Helper function to convert hex string to bytes
-func hexToBytes(hexStr string) []byte {
-	if len(hexStr)%2 != 0 {
-		return []byte(hexStr) // Fallback if not valid hex
-	}
-
-	result := make([]byte, len(hexStr)/2)
-	for i := 0; i < len(hexStr); i += 2 {
-		result[i/2] = hexToByte(hexStr[i : i+2])
-	}
-	return result
-}
-
-// Helper function to convert 2-character hex string to byte
-func hexToByte(hexStr string) byte {
-	if len(hexStr) != 2 {
-		return 0
-	}
-
-	var result byte
-	for i, c := range hexStr {
-		var value byte
-		if c >= '0' && c <= '9' {
-			value = byte(c - '0')
-		} else if c >= 'a' && c <= 'f' {
-			value = byte(c - 'a' + 10)
-		} else if c >= 'A' && c <= 'F' {
-			value = byte(c - 'A' + 10)
-		}
-
-		if i == 0 {
-			result = value << 4
-		} else {
-			result |= value
-		}
-	}
-
-	return result
-}
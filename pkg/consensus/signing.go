@@ -0,0 +1,83 @@
+package consensus
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/proto"
+)
+
+// Domain tags keep a proposal signature from ever being valid as a vote or a
+// transaction signature, and the other way around
+const (
+	proposalDomain = "blockchain-go/proposal"
+	voteDomain     = "blockchain-go/vote"
+)
+
+// Signer signs the block proposals and votes of this node. Implementations
+// usually delegate to a signer daemon so the node never holds the key.
+type Signer interface {
+	SignProposal(ctx context.Context, height int, blockHash string) (*proto.NodeSignature, error)
+	SignVote(ctx context.Context, blockHash, voterID string, approve bool) (*proto.NodeSignature, error)
+}
+
+// ProposalDigest returns the hash signed by the proposer of a block
+func ProposalDigest(height int, blockHash string) []byte {
+	data := append([]byte(proposalDomain), 0)
+	data = binary.BigEndian.AppendUint64(data, uint64(height))
+	data = append(data, blockHash...)
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// VoteDigest returns the hash signed by a node voting on a block
+func VoteDigest(blockHash, voterID string, approve bool) []byte {
+	data := append([]byte(voteDomain), 0)
+	data = append(data, blockHash...)
+	data = append(data, 0)
+	data = append(data, voterID...)
+	if approve {
+		data = append(data, 1)
+	} else {
+		data = append(data, 0)
+	}
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// VerifyNodeSignature checks a proposal or vote signature and returns the
+// address of the key that made it
+func VerifyNodeSignature(sig *proto.NodeSignature, digest []byte) ([]byte, error) {
+	verifier, err := blockchain.ParseVerifier(blockchain.SignatureScheme(sig.Scheme), sig.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifier.Verify(digest, sig.Signature); err != nil {
+		return nil, err
+	}
+	return verifier.Address(), nil
+}
+
+// checkSigner verifies an optional proposal or vote signature. Once a
+// validator set is configured, signatures are required and must come from
+// one of its keys.
+func (ce *ConsensusEngine) checkSigner(sig *proto.NodeSignature, digest []byte) ([]byte, error) {
+	if sig == nil {
+		if len(ce.validators) > 0 {
+			return nil, fmt.Errorf("missing signature")
+		}
+		return nil, nil
+	}
+
+	address, err := VerifyNodeSignature(sig, digest)
+	if err != nil {
+		return nil, fmt.Errorf("bad signature: %w", err)
+	}
+	if len(ce.validators) > 0 && !ce.validators[string(address)] {
+		return nil, fmt.Errorf("%x is not a validator", address)
+	}
+	return address, nil
+}
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"log"
@@ -11,7 +12,9 @@ import (
 
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/consensus"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/protoconv"
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/storage"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
 	"github.com/nguyentrinhquy1411/blockchain-go/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -21,9 +24,11 @@ type BlockchainServer struct {
 	proto.UnimplementedBlockchainServiceServer
 	nodeID     string
 	blockchain *blockchain.Blockchain
-	storage    *storage.LevelDB
+	storage    *storage.Store
 	peers      []string
 	isLeader   bool
+	txPool     *blockchain.TxPool
+	minFee     float64 // Minimum fee accepted by SendTransaction
 
 	// Consensus engines
 	consensusEngine *consensus.ConsensusEngine
@@ -38,30 +43,56 @@ type BlockchainServer struct {
 	voteChan     chan *proto.VoteRequest
 }
 
-func NewBlockchainServer(nodeID string, blockchain *blockchain.Blockchain, storage *storage.LevelDB, peers []string, isLeader bool) *BlockchainServer {
+// DefaultMinFee is the minimum transaction fee accepted unless configured otherwise
+const DefaultMinFee = 0.01
+
+func NewBlockchainServer(nodeID string, bc *blockchain.Blockchain, storage *storage.Store, peers []string, isLeader bool) *BlockchainServer {
 	server := &BlockchainServer{
 		nodeID:       nodeID,
-		blockchain:   blockchain,
+		blockchain:   bc,
 		storage:      storage,
 		peers:        peers,
 		isLeader:     isLeader,
+		txPool:       blockchain.NewTxPool(),
+		minFee:       DefaultMinFee,
 		votes:        make(map[string]int),
 		proposalChan: make(chan *proto.Block, 10),
 		voteChan:     make(chan *proto.VoteRequest, 10),
 	}
 
 	// Initialize consensus engines
-	server.consensusEngine = consensus.NewConsensusEngine(nodeID, blockchain, peers, isLeader)
-	server.recoveryEngine = consensus.NewRecoveryEngine(nodeID, blockchain, peers, isLeader)
+	server.consensusEngine = consensus.NewConsensusEngine(nodeID, bc, server.txPool, peers, isLeader)
+	server.recoveryEngine = consensus.NewRecoveryEngine(nodeID, bc, peers, isLeader)
 
 	return server
 }
 
+// SetMinFee sets the minimum fee a transaction must pay to be accepted
+func (s *BlockchainServer) SetMinFee(fee float64) {
+	s.minFee = fee
+}
+
+// SetSigner makes the node sign its block proposals and votes
+func (s *BlockchainServer) SetSigner(signer consensus.Signer) {
+	s.consensusEngine.SetSigner(signer)
+}
+
+// SetValidators only accepts proposals and votes signed by these addresses
+func (s *BlockchainServer) SetValidators(addresses [][]byte) {
+	s.consensusEngine.SetValidators(addresses)
+}
+
+// SetRewardAddress sets the address credited with the reward and fees of
+// blocks proposed by this node
+func (s *BlockchainServer) SetRewardAddress(address []byte) {
+	s.consensusEngine.SetRewardAddress(address)
+}
+
 func (s *BlockchainServer) ProposeBlock(ctx context.Context, req *proto.ProposeBlockRequest) (*proto.ProposeBlockResponse, error) {
 	log.Printf("[%s] P2P: Received block proposal from %s", s.nodeID, req.ProposerId)
 
 	// Use the new consensus engine to process the proposal
-	accepted, message := s.consensusEngine.ProcessBlockProposal(req.ProposerId, req.Block)
+	accepted, message := s.consensusEngine.ProcessBlockProposal(req.ProposerId, req.Block, req.Signature)
 
 	return &proto.ProposeBlockResponse{
 		Accepted: accepted,
@@ -73,7 +104,7 @@ func (s *BlockchainServer) Vote(ctx context.Context, req *proto.VoteRequest) (*p
 	log.Printf("[%s] P2P: Received vote from %s for block %s: %v", s.nodeID, req.VoterId, req.BlockHash[:8], req.Approve)
 
 	// Use the new consensus engine to process the vote
-	success, message := s.consensusEngine.ProcessVote(req.VoterId, req.BlockHash, req.Approve)
+	success, message := s.consensusEngine.ProcessVote(req.VoterId, req.BlockHash, req.Approve, req.Signature)
 
 	return &proto.VoteResponse{
 		Success: success,
@@ -131,43 +162,375 @@ func (s *BlockchainServer) broadcastCommittedBlock(block *blockchain.Block) {
 }
 
 func (s *BlockchainServer) SendTransaction(ctx context.Context, req *proto.SendTransactionRequest) (*proto.SendTransactionResponse, error) {
-	log.Printf("[%s] Received transaction: %s -> %s (%.2f)", s.nodeID, req.Transaction.Sender, req.Transaction.Receiver, req.Transaction.Amount)
+	// Convert proto transaction to internal transaction, resolving names to addresses
+	tx, err := protoconv.TransactionFromProto(req.Transaction)
+	if err == nil {
+		err = s.resolveParticipants(tx, req.Transaction)
+	}
+	if err != nil {
+		log.Printf("[%s] Rejected transaction: %v", s.nodeID, err)
+		return &proto.SendTransactionResponse{
+			Accepted: false,
+			Message:  err.Error(),
+		}, nil
+	}
+	log.Printf("[%s] Received transaction: %s -> %s (%.2f)", s.nodeID,
+		wallet.FormatAddress(tx.Sender), wallet.FormatAddress(tx.Receiver), tx.Amount)
 
-	// Convert proto transaction to internal transaction
-	tx := s.protoToTransaction(req.Transaction)
+	if tx.IsCoinbase() {
+		return &proto.SendTransactionResponse{
+			Accepted: false,
+			Message:  "System transactions cannot be submitted",
+		}, nil
+	}
 
-	// Basic validation
-	if tx.Amount <= 0 {
+	// Whether the key may sign for the sender (it may have been rotated) is
+	// checked against the state below
+	if err := tx.VerifySignature(); err != nil {
 		return &proto.SendTransactionResponse{
 			Accepted: false,
-			Message:  "Invalid transaction amount",
+			Message:  fmt.Sprintf("Invalid signature: %v", err),
 		}, nil
 	}
 
-	// If this is the leader, we could immediately create a block
-	// For now, just accept the transaction
-	if s.isLeader {
-		log.Printf("[%s] Leader received transaction, will include in next block", s.nodeID)
-		// In a full implementation, add to transaction pool
-		// s.addToTransactionPool(tx)
-	} else {
+	if tx.Fee < s.minFee {
+		return &proto.SendTransactionResponse{
+			Accepted: false,
+			Message:  fmt.Sprintf("Fee %.4f is below the minimum fee %.4f", tx.Fee, s.minFee),
+		}, nil
+	}
+
+	// Validate against the current state (amount, fee, balances, assets)
+	if err := s.blockchain.CheckTransaction(tx); err != nil {
+		return &proto.SendTransactionResponse{
+			Accepted: false,
+			Message:  fmt.Sprintf("Invalid transaction: %v", err),
+		}, nil
+	}
+
+	if !s.isLeader {
 		// Forward to leader if we're a follower
 		log.Printf("[%s] Follower received transaction, forwarding to leader", s.nodeID)
-		// In a full implementation, forward to leader node
+		return s.forwardTransaction(ctx, &proto.SendTransactionRequest{Transaction: protoconv.TransactionToProto(tx)})
 	}
 
+	log.Printf("[%s] Leader received transaction, will include in next block", s.nodeID)
+	if err := s.txPool.Add(tx); err != nil {
+		return &proto.SendTransactionResponse{
+			Accepted: false,
+			Message:  err.Error(),
+		}, nil
+	}
+
+	hash, _ := tx.Hash() // Add already hashed it
 	return &proto.SendTransactionResponse{
 		Accepted: true,
-		Message:  "Transaction accepted",
+		Message:  fmt.Sprintf("Transaction %x accepted", hash),
+		TxHash:   hex.EncodeToString(hash),
+	}, nil
+}
+
+// resolveParticipants replaces the sender and receiver of a transaction with
+// the addresses they name. Each may be an address or a registered name.
+// Signatures cover the resolved addresses, so clients resolve names before
+// signing.
+func (s *BlockchainServer) resolveParticipants(tx *blockchain.Transaction, pt *proto.Transaction) error {
+	sender, err := s.resolveAccount(pt.Sender)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	tx.Sender = sender
+
+	if pt.Receiver != "" {
+		receiver, err := s.resolveAccount(pt.Receiver)
+		if err != nil {
+			return fmt.Errorf("invalid receiver: %w", err)
+		}
+		tx.Receiver = receiver
+	}
+	return nil
+}
+
+// resolveAccount turns an address (Bech32 or legacy hex) or a registered
+// name into an address. Mistyped addresses fail their checksum instead of
+// being looked up as names.
+func (s *BlockchainServer) resolveAccount(account string) ([]byte, error) {
+	if wallet.LooksLikeAddress(account) {
+		return wallet.ParseAddress(account)
+	}
+
+	record, err := s.blockchain.ResolveName(account)
+	if err != nil {
+		return nil, fmt.Errorf("%q is neither an address nor a valid name: %w", account, err)
+	}
+	if record == nil {
+		return nil, fmt.Errorf("unknown name %q", account)
+	}
+	return record.Owner, nil
+}
+
+// forwardTransaction relays a transaction received by a follower to the leader
+func (s *BlockchainServer) forwardTransaction(ctx context.Context, req *proto.SendTransactionRequest) (*proto.SendTransactionResponse, error) {
+	leaderAddr := s.leaderAddress()
+	if leaderAddr == "" {
+		return &proto.SendTransactionResponse{
+			Accepted: false,
+			Message:  "Leader not found in peers list",
+		}, nil
+	}
+
+	conn, err := grpc.NewClient(leaderAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to leader %s: %w", leaderAddr, err)
+	}
+	defer conn.Close()
+
+	client := proto.NewBlockchainServiceClient(conn)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return client.SendTransaction(ctx, req)
+}
+
+func (s *BlockchainServer) ListAssets(ctx context.Context, req *proto.ListAssetsRequest) (*proto.ListAssetsResponse, error) {
+	assets, err := s.blockchain.GetAssets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+
+	resp := &proto.ListAssetsResponse{}
+	for _, asset := range assets {
+		resp.Assets = append(resp.Assets, &proto.Asset{
+			Id:       asset.ID,
+			Symbol:   asset.Symbol,
+			Decimals: int32(asset.Decimals),
+			Supply:   asset.Supply,
+			Issuer:   wallet.FormatAddress(asset.Issuer),
+			Height:   int32(asset.Height),
+		})
+	}
+	return resp, nil
+}
+
+func (s *BlockchainServer) GetBalance(ctx context.Context, req *proto.GetBalanceRequest) (*proto.GetBalanceResponse, error) {
+	address, err := wallet.ParseAddress(req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+
+	// A single asset was requested
+	if req.Asset != blockchain.NativeAsset {
+		asset, err := s.blockchain.GetAsset(req.Asset)
+		if err != nil {
+			return nil, err
+		}
+		if asset == nil {
+			return nil, fmt.Errorf("unknown asset %s", req.Asset)
+		}
+		balance, err := s.blockchain.GetBalance(address, asset.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.GetBalanceResponse{
+			Balances: []*proto.AssetBalance{{Asset: asset.ID, Symbol: asset.Symbol, Balance: balance}},
+		}, nil
+	}
+
+	// Otherwise report the native coin followed by every asset held
+	native, err := s.blockchain.GetBalance(address, blockchain.NativeAsset)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.GetBalanceResponse{
+		Balances: []*proto.AssetBalance{{Asset: blockchain.NativeAsset, Symbol: blockchain.NativeAssetSymbol, Balance: native}},
+	}
+
+	assets, err := s.blockchain.GetAssets()
+	if err != nil {
+		return nil, err
+	}
+	for _, asset := range assets {
+		balance, err := s.blockchain.GetBalance(address, asset.ID)
+		if err != nil {
+			return nil, err
+		}
+		if balance > 0 {
+			resp.Balances = append(resp.Balances, &proto.AssetBalance{Asset: asset.ID, Symbol: asset.Symbol, Balance: balance})
+		}
+	}
+	return resp, nil
+}
+
+func (s *BlockchainServer) ResolveName(ctx context.Context, req *proto.ResolveNameRequest) (*proto.ResolveNameResponse, error) {
+	record, err := s.blockchain.ResolveName(req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name: %w", err)
+	}
+	if record == nil {
+		return &proto.ResolveNameResponse{Found: false}, nil
+	}
+
+	return &proto.ResolveNameResponse{
+		Found:   true,
+		Name:    record.Name,
+		Address: wallet.FormatAddress(record.Owner),
+		Expiry:  int32(record.Expiry),
+	}, nil
+}
+
+func (s *BlockchainServer) GetAnchor(ctx context.Context, req *proto.GetAnchorRequest) (*proto.GetAnchorResponse, error) {
+	hash, err := hex.DecodeString(req.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hash: %w", err)
+	}
+
+	receipt, err := s.blockchain.GetAnchor(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anchor: %w", err)
+	}
+	if receipt == nil {
+		return &proto.GetAnchorResponse{Found: false}, nil
+	}
+
+	var proof []*proto.MerkleProofStep
+	for _, step := range receipt.Proof {
+		proof = append(proof, &proto.MerkleProofStep{
+			Hash: fmt.Sprintf("%x", step.Hash),
+			Left: step.Left,
+		})
+	}
+
+	return &proto.GetAnchorResponse{
+		Found:       true,
+		Hash:        fmt.Sprintf("%x", receipt.Hash),
+		ContentType: receipt.ContentType,
+		Height:      int32(receipt.Height),
+		Timestamp:   receipt.Timestamp,
+		BlockHash:   fmt.Sprintf("%x", receipt.BlockHash),
+		MerkleRoot:  fmt.Sprintf("%x", receipt.MerkleRoot),
+		TxIndex:     int32(receipt.TxIndex),
+		Transaction: protoconv.TransactionToProto(receipt.Transaction),
+		Proof:       proof,
 	}, nil
 }
 
+func (s *BlockchainServer) GetAllowances(ctx context.Context, req *proto.GetAllowancesRequest) (*proto.GetAllowancesResponse, error) {
+	owner, err := s.resolveAccount(req.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner: %w", err)
+	}
+	var spender []byte
+	if req.Spender != "" {
+		if spender, err = s.resolveAccount(req.Spender); err != nil {
+			return nil, fmt.Errorf("invalid spender: %w", err)
+		}
+	}
+
+	allowances, err := s.blockchain.GetAllowances(owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowances: %w", err)
+	}
+
+	resp := &proto.GetAllowancesResponse{}
+	for _, allowance := range allowances {
+		if spender != nil && string(allowance.Spender) != string(spender) {
+			continue
+		}
+		resp.Allowances = append(resp.Allowances, &proto.Allowance{
+			Owner:   wallet.FormatAddress(allowance.Owner),
+			Spender: wallet.FormatAddress(allowance.Spender),
+			Asset:   allowance.Asset,
+			Amount:  allowance.Amount,
+		})
+	}
+	return resp, nil
+}
+
+func (s *BlockchainServer) GetVesting(ctx context.Context, req *proto.GetVestingRequest) (*proto.GetVestingResponse, error) {
+	address, err := s.resolveAccount(req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+
+	status, err := s.blockchain.GetVesting(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vesting: %w", err)
+	}
+
+	resp := &proto.GetVestingResponse{
+		Address:   wallet.FormatAddress(status.Address),
+		Height:    int64(status.Height),
+		Balance:   status.Balance,
+		Vested:    status.Vested,
+		Locked:    status.Locked,
+		Spendable: status.Spendable(),
+	}
+	for _, schedule := range status.Schedules {
+		resp.Schedules = append(resp.Schedules, &proto.VestingSchedule{
+			Start:  int64(schedule.Start),
+			Cliff:  int64(schedule.Cliff),
+			End:    int64(schedule.End),
+			Total:  schedule.Total,
+			Vested: schedule.Vested(status.Height),
+		})
+	}
+	return resp, nil
+}
+
+func (s *BlockchainServer) GetAccount(ctx context.Context, req *proto.GetAccountRequest) (*proto.GetAccountResponse, error) {
+	address, err := s.resolveAccount(req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+
+	nonce, err := s.blockchain.GetNonce(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	// Transactions waiting in the pool will use the nonces before the next one
+	for _, tx := range s.txPool.Pending() {
+		if string(tx.Sender) == string(address) {
+			nonce++
+		}
+	}
+
+	balance, err := s.blockchain.GetBalance(address, blockchain.NativeAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.GetAccountResponse{
+		Address: wallet.FormatAddress(address),
+		ChainId: s.blockchain.ChainID(),
+		Nonce:   nonce + 1,
+		Balance: balance,
+		MinFee:  s.minFee,
+		Height:  int64(s.blockchain.GetLatestBlock().Index),
+	}
+	key, err := s.blockchain.GetPublicKey(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key: %w", err)
+	}
+	if key != nil {
+		resp.KeyScheme = wallet.SchemeName(key.Scheme)
+		resp.PublicKey = key.PublicKey
+	}
+	rotated, err := s.blockchain.GetAuthorizedKey(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorized key: %w", err)
+	}
+	if rotated != nil {
+		resp.RotatedAt = int64(rotated.Height)
+	}
+	return resp, nil
+}
+
 func (s *BlockchainServer) GetLatestBlock(ctx context.Context, req *proto.GetLatestBlockRequest) (*proto.GetLatestBlockResponse, error) {
 	// Get latest block from blockchain
 	latestBlock := s.blockchain.GetLatestBlock()
 
 	return &proto.GetLatestBlockResponse{
-		Block:  s.blockToProto(latestBlock),
+		Block:  protoconv.BlockToProto(latestBlock),
 		Height: int32(latestBlock.Index),
 	}, nil
 }
@@ -192,85 +555,115 @@ func (s *BlockchainServer) GetBlock(ctx context.Context, req *proto.GetBlockRequ
 	}
 
 	return &proto.GetBlockResponse{
-		Block: s.blockToProto(block),
+		Block: protoconv.BlockToProto(block),
 		Found: true,
 	}, nil
 }
 
-func (s *BlockchainServer) SyncBlocks(ctx context.Context, req *proto.SyncBlocksRequest) (*proto.SyncBlocksResponse, error) {
-	var blocks []*proto.Block
-
-	for height := req.FromHeight; height <= req.ToHeight; height++ {
-		block, err := s.blockchain.GetBlockByHeight(int(height))
-		if err != nil {
-			break
-		}
-		blocks = append(blocks, s.blockToProto(block))
+func (s *BlockchainServer) GetTransaction(ctx context.Context, req *proto.GetTransactionRequest) (*proto.GetTransactionResponse, error) {
+	hash, err := hex.DecodeString(req.Hash)
+	if err != nil || len(hash) != sha256.Size {
+		return nil, fmt.Errorf("invalid transaction hash %q", req.Hash)
 	}
 
-	return &proto.SyncBlocksResponse{
-		Blocks: blocks,
+	tx, block, position, err := s.blockchain.GetTransaction(hash)
+	if err != nil {
+		if pending := s.txPool.Get(hash); pending != nil {
+			return &proto.GetTransactionResponse{
+				Found:       true,
+				Transaction: protoconv.TransactionToProto(pending),
+				Pending:     true,
+			}, nil
+		}
+		return &proto.GetTransactionResponse{Found: false}, nil
+	}
+
+	latest := s.blockchain.GetLatestBlock().Index
+	return &proto.GetTransactionResponse{
+		Found:          true,
+		Transaction:    protoconv.TransactionToProto(tx),
+		BlockHeight:    int64(block.Index),
+		BlockHash:      fmt.Sprintf("%x", block.CurrentBlockHash),
+		BlockTimestamp: block.Timestamp,
+		Position:       int32(position),
+		Confirmations:  int64(latest - block.Index + 1),
 	}, nil
 }
 
-// Helper functions for conversion
-func (s *BlockchainServer) protoToBlock(pb *proto.Block) *blockchain.Block {
-	var transactions []*blockchain.Transaction
-	for _, tx := range pb.Transactions {
-		transactions = append(transactions, s.protoToTransaction(tx))
-	}
-
-	previousHash, _ := hex.DecodeString(pb.PreviousHash)
-	merkleRoot, _ := hex.DecodeString(pb.MerkleRoot)
-	currentHash, _ := hex.DecodeString(pb.Hash)
+// Page sizes of GetAddressHistory
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
 
-	return &blockchain.Block{
-		Index:             int(pb.Height),
-		PreviousBlockHash: previousHash,
-		MerkleRoot:        merkleRoot,
-		Timestamp:         pb.Timestamp,
-		Transactions:      transactions,
-		CurrentBlockHash:  currentHash,
+func (s *BlockchainServer) GetAddressHistory(ctx context.Context, req *proto.GetAddressHistoryRequest) (*proto.GetAddressHistoryResponse, error) {
+	address, err := s.resolveAccount(req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
 	}
-}
+	direction, err := blockchain.ParseDirection(req.Direction)
+	if err != nil {
+		return nil, err
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+	limit = min(limit, maxHistoryLimit)
 
-func (s *BlockchainServer) blockToProto(block *blockchain.Block) *proto.Block {
-	var transactions []*proto.Transaction
-	for _, tx := range block.Transactions {
-		transactions = append(transactions, s.transactionToProto(tx))
+	page, err := s.blockchain.GetAddressHistory(address, blockchain.HistoryQuery{
+		Direction:   direction,
+		Cursor:      req.Cursor,
+		Limit:       limit,
+		OldestFirst: req.OldestFirst,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return &proto.Block{
-		Height:       int32(block.Index),
-		PreviousHash: fmt.Sprintf("%x", block.PreviousBlockHash),
-		MerkleRoot:   fmt.Sprintf("%x", block.MerkleRoot),
-		Timestamp:    block.Timestamp,
-		Transactions: transactions,
-		Hash:         fmt.Sprintf("%x", block.CurrentBlockHash),
+	resp := &proto.GetAddressHistoryResponse{
+		Address:    wallet.FormatAddress(address),
+		NextCursor: page.Next,
 	}
+	blocks := make(map[int]*blockchain.Block) // Pages often hold several transactions of one block
+	for _, entry := range page.Entries {
+		block, ok := blocks[entry.Height]
+		if !ok {
+			if block, err = s.blockchain.GetBlockByHeight(entry.Height); err != nil {
+				return nil, err
+			}
+			blocks[entry.Height] = block
+		}
+		if entry.Position >= len(block.Transactions) {
+			return nil, fmt.Errorf("address history of %s points past block %d", resp.Address, entry.Height)
+		}
+		resp.Entries = append(resp.Entries, &proto.AddressHistoryEntry{
+			TxHash:         hex.EncodeToString(entry.Hash),
+			BlockHeight:    int64(entry.Height),
+			Position:       int32(entry.Position),
+			BlockTimestamp: block.Timestamp,
+			Sent:           entry.Sent,
+			Received:       entry.Received,
+			Transaction:    protoconv.TransactionToProto(block.Transactions[entry.Position]),
+		})
+	}
+	return resp, nil
 }
 
-func (s *BlockchainServer) protoToTransaction(pt *proto.Transaction) *blockchain.Transaction {
-	sender, _ := hex.DecodeString(pt.Sender)
-	receiver, _ := hex.DecodeString(pt.Receiver)
+func (s *BlockchainServer) SyncBlocks(ctx context.Context, req *proto.SyncBlocksRequest) (*proto.SyncBlocksResponse, error) {
+	var blocks []*proto.Block
 
-	return &blockchain.Transaction{
-		Sender:    sender,
-		Receiver:  receiver,
-		Amount:    pt.Amount,
-		Timestamp: pt.Timestamp,
-		Signature: pt.Signature,
+	for height := req.FromHeight; height <= req.ToHeight; height++ {
+		block, err := s.blockchain.GetBlockByHeight(int(height))
+		if err != nil {
+			break
+		}
+		blocks = append(blocks, protoconv.BlockToProto(block))
 	}
-}
 
-func (s *BlockchainServer) transactionToProto(tx *blockchain.Transaction) *proto.Transaction {
-	return &proto.Transaction{
-		Sender:    fmt.Sprintf("%x", tx.Sender),
-		Receiver:  fmt.Sprintf("%x", tx.Receiver),
-		Amount:    tx.Amount,
-		Timestamp: tx.Timestamp,
-		Signature: tx.Signature,
-	}
+	return &proto.SyncBlocksResponse{
+		Blocks: blocks,
+	}, nil
 }
 
 func (s *BlockchainServer) validateBlock(block *blockchain.Block) bool {
@@ -281,7 +674,7 @@ func (s *BlockchainServer) validateBlock(block *blockchain.Block) bool {
 
 	// Validate each transaction
 	for _, tx := range block.Transactions {
-		if tx.Amount <= 0 {
+		if err := tx.Validate(); err != nil {
 			return false
 		}
 	}
@@ -297,15 +690,7 @@ func (s *BlockchainServer) sendVote(blockHash string, approve bool) {
 		return
 	}
 
-	// Find leader peer (node1 is always leader)
-	var leaderAddr string
-	for _, peer := range s.peers {
-		if peer == "node1:50051" || peer == "localhost:50051" || peer == "127.0.0.1:50051" {
-			leaderAddr = peer
-			break
-		}
-	}
-
+	leaderAddr := s.leaderAddress()
 	if leaderAddr == "" {
 		log.Printf("[%s] Leader not found in peers list", s.nodeID)
 		return
@@ -336,6 +721,16 @@ func (s *BlockchainServer) sendVote(blockHash string, approve bool) {
 	}()
 }
 
+// leaderAddress finds the leader peer (node1 is always leader)
+func (s *BlockchainServer) leaderAddress() string {
+	for _, peer := range s.peers {
+		if peer == "node1:50051" || peer == "localhost:50051" || peer == "127.0.0.1:50051" {
+			return peer
+		}
+	}
+	return ""
+}
+
 func (s *BlockchainServer) StartServer(port string) error {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
@@ -394,7 +789,7 @@ func (s *BlockchainServer) proposeNewBlock() {
 	newBlock := blockchain.NewBlock(latestBlock.Index+1, transactions, latestBlock.CurrentBlockHash)
 
 	// Convert to proto and send to followers for voting
-	protoBlock := s.blockToProto(newBlock)
+	protoBlock := protoconv.BlockToProto(newBlock)
 	blockHash := fmt.Sprintf("%x", newBlock.CurrentBlockHash)
 
 	log.Printf("[%s] 📦 Created block %d with hash %s", s.nodeID, newBlock.Index, blockHash[:8])
@@ -505,7 +900,11 @@ func (s *BlockchainServer) syncWithPeer(peerAddr string) bool {
 	// Process synced blocks
 	syncedCount := 0
 	for _, protoBlock := range syncResp.Blocks {
-		block := s.protoToBlock(protoBlock)
+		block, err := protoconv.BlockFromProto(protoBlock)
+		if err != nil {
+			log.Printf("[%s] ❌ Invalid synced block %d: %v", s.nodeID, protoBlock.Height, err)
+			return false
+		}
 		if err := s.blockchain.AddBlock(block); err != nil {
 			log.Printf("[%s] ❌ Failed to add synced block %d: %v", s.nodeID, block.Index, err)
 			return false
@@ -0,0 +1,110 @@
+// Package protoconv converts blocks and transactions to and from their gRPC
+// messages. Addresses and hashes travel as hex (see proto.Transaction): peers
+// decode them back to raw bytes and system senders have no Bech32 form.
+package protoconv
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/proto"
+)
+
+// TransactionToProto encodes a transaction for clients and peers
+func TransactionToProto(tx *blockchain.Transaction) *proto.Transaction {
+	return &proto.Transaction{
+		Sender:    hex.EncodeToString(tx.Sender),
+		Receiver:  hex.EncodeToString(tx.Receiver),
+		Amount:    tx.Amount,
+		Timestamp: tx.Timestamp,
+		Signature: tx.Signature,
+		Type:      string(tx.Type),
+		Asset:     tx.Asset,
+		Data:      tx.Data,
+		Fee:       tx.Fee,
+		PublicKey: tx.PublicKey,
+		Scheme:    string(tx.Scheme),
+		Nonce:     tx.Nonce,
+		ChainId:   tx.ChainID,
+		Memo:      tx.Memo,
+	}
+}
+
+// TransactionFromProto decodes a transaction, rejecting addresses that are
+// not hex
+func TransactionFromProto(pt *proto.Transaction) (*blockchain.Transaction, error) {
+	if pt == nil {
+		return nil, fmt.Errorf("missing transaction")
+	}
+	sender, err := hex.DecodeString(pt.Sender)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender: %w", err)
+	}
+	receiver, err := hex.DecodeString(pt.Receiver)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receiver: %w", err)
+	}
+	return &blockchain.Transaction{
+		Sender:    sender,
+		Receiver:  receiver,
+		Amount:    pt.Amount,
+		Timestamp: pt.Timestamp,
+		Signature: pt.Signature,
+		Type:      blockchain.TxType(pt.Type),
+		Asset:     pt.Asset,
+		Data:      pt.Data,
+		Fee:       pt.Fee,
+		PublicKey: pt.PublicKey,
+		Scheme:    blockchain.SignatureScheme(pt.Scheme),
+		Nonce:     pt.Nonce,
+		ChainID:   pt.ChainId,
+		Memo:      pt.Memo,
+	}, nil
+}
+
+// BlockToProto encodes a block and its transactions
+func BlockToProto(block *blockchain.Block) *proto.Block {
+	var transactions []*proto.Transaction
+	for _, tx := range block.Transactions {
+		transactions = append(transactions, TransactionToProto(tx))
+	}
+	return &proto.Block{
+		Height:       int32(block.Index),
+		PreviousHash: hex.EncodeToString(block.PreviousBlockHash),
+		MerkleRoot:   hex.EncodeToString(block.MerkleRoot),
+		Timestamp:    block.Timestamp,
+		Transactions: transactions,
+		Hash:         hex.EncodeToString(block.CurrentBlockHash),
+	}
+}
+
+// BlockFromProto decodes a block, rejecting hashes or addresses that are not
+// hex
+func BlockFromProto(pb *proto.Block) (*blockchain.Block, error) {
+	if pb == nil {
+		return nil, fmt.Errorf("missing block")
+	}
+	block := &blockchain.Block{
+		Index:     int(pb.Height),
+		Timestamp: pb.Timestamp,
+	}
+	var err error
+	if block.PreviousBlockHash, err = hex.DecodeString(pb.PreviousHash); err != nil {
+		return nil, fmt.Errorf("invalid previous hash: %w", err)
+	}
+	if block.MerkleRoot, err = hex.DecodeString(pb.MerkleRoot); err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+	if block.CurrentBlockHash, err = hex.DecodeString(pb.Hash); err != nil {
+		return nil, fmt.Errorf("invalid hash: %w", err)
+	}
+	for i, pt := range pb.Transactions {
+		tx, err := TransactionFromProto(pt)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+	return block, nil
+}
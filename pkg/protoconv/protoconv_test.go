@@ -0,0 +1,64 @@
+package protoconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/proto"
+)
+
+func TestBlockRoundTrip(t *testing.T) {
+	block := &blockchain.Block{
+		Index:             3,
+		PreviousBlockHash: []byte{0x01, 0x02},
+		MerkleRoot:        []byte{0x03},
+		Timestamp:         1700000000,
+		CurrentBlockHash:  []byte{0x04, 0x05},
+		Transactions: []*blockchain.Transaction{{
+			Sender:    []byte{0xaa},
+			Receiver:  []byte{0xbb, 0xcc},
+			Amount:    1.5,
+			Timestamp: 1700000000,
+			Signature: []byte("sig"),
+			Type:      blockchain.TxType("asset_transfer"),
+			Asset:     "GOLD",
+			Data:      []byte("data"),
+			Fee:       0.1,
+			PublicKey: []byte("key"),
+			Scheme:    blockchain.SignatureScheme("ed25519"),
+			Nonce:     7,
+			ChainID:   "test",
+			Memo:      []byte("memo"),
+		}},
+	}
+	got, err := BlockFromProto(BlockToProto(block))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, block) {
+		t.Errorf("round trip = %+v, want %+v", got, block)
+	}
+}
+
+func TestFromProtoRejectsBadHex(t *testing.T) {
+	tests := []struct {
+		name  string
+		block *proto.Block
+	}{
+		{"missing block", nil},
+		{"previous hash", &proto.Block{PreviousHash: "zz"}},
+		{"merkle root", &proto.Block{MerkleRoot: "abc"}},
+		{"hash", &proto.Block{Hash: "xy"}},
+		{"missing transaction", &proto.Block{Transactions: []*proto.Transaction{nil}}},
+		{"sender", &proto.Block{Transactions: []*proto.Transaction{{Sender: "alice"}}}},
+		{"receiver", &proto.Block{Transactions: []*proto.Transaction{{Receiver: "bob"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := BlockFromProto(tt.block); err == nil {
+				t.Error("BlockFromProto() accepted a malformed block")
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/protoconv"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+	"github.com/nguyentrinhquy1411/blockchain-go/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TransactionSigner signs transactions for a single address, either with a
+// key unlocked in this process or through the signer daemon
+type TransactionSigner interface {
+	Address() []byte
+	SignTransaction(ctx context.Context, tx *blockchain.Transaction) error
+}
+
+// Local signs transactions with a key held in this process
+func Local(signer wallet.Signer) TransactionSigner {
+	return localSigner{signer}
+}
+
+type localSigner struct {
+	wallet.Signer
+}
+
+func (l localSigner) SignTransaction(ctx context.Context, tx *blockchain.Transaction) error {
+	return wallet.SignTransactionWith(tx, l.Signer)
+}
+
+// Client talks to a signer daemon over its Unix socket
+type Client struct {
+	conn   *grpc.ClientConn
+	client proto.SignerServiceClient
+}
+
+// Dial connects to the signer daemon listening on socketPath
+func Dial(socketPath string) (*Client, error) {
+	conn, err := grpc.NewClient("unix:"+socketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to signer %s: %w", socketPath, err)
+	}
+	return &Client{conn: conn, client: proto.NewSignerServiceClient(conn)}, nil
+}
+
+// DialKey connects to the daemon on socketPath and looks up a named key
+func DialKey(socketPath, name string) (*RemoteKey, error) {
+	client, err := Dial(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key, err := client.Key(ctx, name)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return key, nil
+}
+
+// Close closes the connection to the daemon
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Keys lists the keys served by the daemon
+func (c *Client) Keys(ctx context.Context) ([]*proto.SignerKey, error) {
+	resp, err := c.client.ListKeys(ctx, &proto.ListKeysRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signer keys: %w", err)
+	}
+	return resp.Keys, nil
+}
+
+// Key returns a handle on a named key of the daemon
+func (c *Client) Key(ctx context.Context, name string) (*RemoteKey, error) {
+	keys, err := c.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if key.Name != name {
+			continue
+		}
+		address, err := wallet.ParseAddress(key.Address)
+		if err != nil {
+			return nil, fmt.Errorf("signer key %s: %w", name, err)
+		}
+		return &RemoteKey{client: c.client, name: name, address: address}, nil
+	}
+	return nil, fmt.Errorf("signer has no key named %q", name)
+}
+
+// RemoteKey signs with a key held by the signer daemon. It implements
+// TransactionSigner and consensus.Signer.
+type RemoteKey struct {
+	client  proto.SignerServiceClient
+	name    string
+	address []byte
+}
+
+// Name returns the daemon's name for the key
+func (k *RemoteKey) Name() string {
+	return k.name
+}
+
+// Address returns the address of the key
+func (k *RemoteKey) Address() []byte {
+	return k.address
+}
+
+// SignTransaction asks the daemon to sign a transaction, subject to the
+// key's policy
+func (k *RemoteKey) SignTransaction(ctx context.Context, tx *blockchain.Transaction) error {
+	sig, err := k.client.SignTransaction(ctx, &proto.SignTransactionRequest{
+		Key:         k.name,
+		Transaction: protoconv.TransactionToProto(tx),
+	})
+	if err != nil {
+		return err
+	}
+
+	tx.Scheme = blockchain.SignatureScheme(sig.Scheme)
+	tx.PublicKey = sig.PublicKey
+	tx.Signature = sig.Signature
+	return tx.VerifySignature()
+}
+
+// SignProposal asks the daemon to sign a block proposal
+func (k *RemoteKey) SignProposal(ctx context.Context, height int, blockHash string) (*proto.NodeSignature, error) {
+	return k.client.SignProposal(ctx, &proto.SignProposalRequest{
+		Key:       k.name,
+		Height:    int64(height),
+		BlockHash: blockHash,
+	})
+}
+
+// SignVote asks the daemon to sign a vote
+func (k *RemoteKey) SignVote(ctx context.Context, blockHash, voterID string, approve bool) (*proto.NodeSignature, error) {
+	return k.client.SignVote(ctx, &proto.SignVoteRequest{
+		Key:       k.name,
+		BlockHash: blockHash,
+		VoterId:   voterID,
+		Approve:   approve,
+	})
+}
@@ -0,0 +1,167 @@
+package signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+)
+
+// Policy limits what the signer daemon will sign with a key. Transfers are
+// always allowed; other transaction types only when AllowedTypes names them,
+// and key rotations, which hand the account to another key and so escape
+// every limit, only with AllowRotation.
+// The limits apply to every type. MaxAmount counts native coins only: the fee
+// plus a native amount (issue_asset mints its amount, so only its fee
+// counts). Amounts of other assets count against that asset's AssetLimits
+// entry; a key with any limit may not move assets it has no entry for. The
+// receiver, such as the spender of an approve or the recipient of a
+// transfer_from or vesting_grant, must be an allowed recipient.
+type Policy struct {
+	MaxAmount         float64            `json:"max_amount,omitempty"`         // Largest native amount plus fee per transaction, 0 for no limit
+	AssetLimits       map[string]float64 `json:"asset_limits,omitempty"`       // Largest amount per transaction of each asset, by asset ID
+	AllowedTypes      []string           `json:"allowed_types,omitempty"`      // Transaction types besides transfers the key may sign
+	AllowedRecipients []string           `json:"allowed_recipients,omitempty"` // Receivers the key may pay, empty for any
+	AllowRotation     bool               `json:"allow_rotation,omitempty"`     // Whether the key may rotate its account to a new key
+	Consensus         bool               `json:"consensus,omitempty"`          // Whether the key may sign block proposals and votes
+}
+
+// policyTypes are the transaction types a policy can allow by name
+var policyTypes = map[string]blockchain.TxType{
+	string(blockchain.TxIssueAsset):   blockchain.TxIssueAsset,
+	string(blockchain.TxNameRegister): blockchain.TxNameRegister,
+	string(blockchain.TxNameRenew):    blockchain.TxNameRenew,
+	string(blockchain.TxNameTransfer): blockchain.TxNameTransfer,
+	string(blockchain.TxAnchor):       blockchain.TxAnchor,
+	string(blockchain.TxApprove):      blockchain.TxApprove,
+	string(blockchain.TxTransferFrom): blockchain.TxTransferFrom,
+	string(blockchain.TxVestingGrant): blockchain.TxVestingGrant,
+}
+
+// check validates the names in the policy
+func (p *Policy) check() error {
+	for _, name := range p.AllowedTypes {
+		if name == string(blockchain.TxRotateKey) {
+			return fmt.Errorf("allow key rotation with allow_rotation, not allowed_types")
+		}
+		if _, ok := policyTypes[name]; !ok {
+			return fmt.Errorf("unknown transaction type %q in allowed_types", name)
+		}
+	}
+	for asset, limit := range p.AssetLimits {
+		if asset == blockchain.NativeAsset {
+			return fmt.Errorf("limit native coins with max_amount, not asset_limits")
+		}
+		if limit < 0 {
+			return fmt.Errorf("negative limit for asset %s in asset_limits", asset)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) allowsType(txType blockchain.TxType) bool {
+	switch txType {
+	case blockchain.TxTransfer:
+		return true
+	case blockchain.TxRotateKey:
+		return p.AllowRotation
+	}
+	for _, name := range p.AllowedTypes {
+		if policyTypes[name] == txType {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTransaction reports why a transaction may not be signed under the
+// policy, or nil if it may
+func (p *Policy) CheckTransaction(tx *blockchain.Transaction) error {
+	if !p.allowsType(tx.Type) {
+		return fmt.Errorf("%s transactions are not allowed for this key", txTypeName(tx.Type))
+	}
+
+	// Native coins and assets have different units, so each has its own limit
+	native, assetAmount := tx.Fee, 0.0
+	switch {
+	case tx.Type == blockchain.TxIssueAsset:
+	case tx.Asset == blockchain.NativeAsset:
+		native += tx.Amount
+	default:
+		assetAmount = tx.Amount
+	}
+	if p.MaxAmount > 0 && native > p.MaxAmount {
+		return fmt.Errorf("amount plus fee %.8f exceeds the key's limit of %.8f", native, p.MaxAmount)
+	}
+	if assetAmount > 0 {
+		limit, ok := p.AssetLimits[tx.Asset]
+		switch {
+		case ok && assetAmount > limit:
+			return fmt.Errorf("amount %.8f of asset %s exceeds the key's limit of %.8f", assetAmount, tx.Asset, limit)
+		case !ok && (p.MaxAmount > 0 || len(p.AssetLimits) > 0):
+			return fmt.Errorf("asset %s has no limit for this key", tx.Asset)
+		}
+	}
+
+	if len(p.AllowedRecipients) == 0 || len(tx.Receiver) == 0 {
+		return nil
+	}
+	for _, recipient := range p.AllowedRecipients {
+		address, err := wallet.ParseAddress(recipient)
+		if err != nil {
+			return fmt.Errorf("invalid allowed recipient: %w", err)
+		}
+		if address.Equal(tx.Receiver) {
+			return nil
+		}
+	}
+	return fmt.Errorf("recipient %s is not allowed for this key", wallet.FormatAddress(tx.Receiver))
+}
+
+// KeyConfig names a keystore file served by the daemon and its policy
+type KeyConfig struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+	Policy
+}
+
+// Config is the signer daemon configuration file, e.g.
+//
+//	{"keys": [
+//	  {"name": "treasury", "file": "treasury_key.json",
+//	   "max_amount": 100, "asset_limits": {"<asset id>": 5000},
+//	   "allowed_recipients": ["bgo1..."], "allowed_types": ["approve"]},
+//	  {"name": "validator", "file": "node1_key.json", "consensus": true}
+//	]}
+type Config struct {
+	Keys []KeyConfig `json:"keys"`
+}
+
+// LoadConfig reads a signer daemon configuration file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signer config: %w", err)
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse signer config: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, key := range config.Keys {
+		if key.Name == "" || key.File == "" {
+			return nil, fmt.Errorf("key %d: name and file are required", i)
+		}
+		if seen[key.Name] {
+			return nil, fmt.Errorf("duplicate key name %q", key.Name)
+		}
+		if err := key.Policy.check(); err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.Name, err)
+		}
+		seen[key.Name] = true
+	}
+	return &config, nil
+}
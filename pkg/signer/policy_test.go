@@ -0,0 +1,84 @@
+package signer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+)
+
+func TestPolicyCheckTransaction(t *testing.T) {
+	friend := bytes.Repeat([]byte{1}, blockchain.AddressLength)
+	stranger := bytes.Repeat([]byte{2}, blockchain.AddressLength)
+	limited := Policy{
+		MaxAmount:         10,
+		AllowedRecipients: []string{wallet.FormatAddress(friend)},
+		AllowedTypes:      []string{"approve", "transfer_from", "issue_asset", "vesting_grant"},
+	}
+	tx := func(txType blockchain.TxType, receiver []byte, amount, fee float64) *blockchain.Transaction {
+		return &blockchain.Transaction{Type: txType, Receiver: receiver, Amount: amount, Fee: fee}
+	}
+	assetTx := func(txType blockchain.TxType, asset string, amount, fee float64) *blockchain.Transaction {
+		return &blockchain.Transaction{Type: txType, Receiver: friend, Asset: asset, Amount: amount, Fee: fee}
+	}
+	withAssets := limited
+	withAssets.AssetLimits = map[string]float64{"gold": 1000}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		tx     *blockchain.Transaction
+		ok     bool
+	}{
+		{"transfer within limit", limited, tx(blockchain.TxTransfer, friend, 9, 0.5), true},
+		{"transfer at limit", limited, tx(blockchain.TxTransfer, friend, 9.5, 0.5), true},
+		{"fee over limit", limited, tx(blockchain.TxTransfer, friend, 0.01, 100), false},
+		{"amount over limit", limited, tx(blockchain.TxTransfer, friend, 11, 0), false},
+		{"transfer to stranger", limited, tx(blockchain.TxTransfer, stranger, 1, 0), false},
+		{"approve friend", limited, tx(blockchain.TxApprove, friend, 5, 0.01), true},
+		{"approve stranger", limited, tx(blockchain.TxApprove, stranger, 5, 0.01), false},
+		{"approve over limit", limited, tx(blockchain.TxApprove, friend, 1000, 0.01), false},
+		{"transfer_from to stranger", limited, tx(blockchain.TxTransferFrom, stranger, 1, 0), false},
+		{"transfer_from over limit", limited, tx(blockchain.TxTransferFrom, friend, 50, 0), false},
+		{"vesting grant over limit", limited, tx(blockchain.TxVestingGrant, friend, 50, 0), false},
+		{"issue asset counts only the fee", limited, tx(blockchain.TxIssueAsset, nil, 1000000, 1), true},
+		{"issue asset fee over limit", limited, tx(blockchain.TxIssueAsset, nil, 1, 20), false},
+		{"type not named", limited, tx(blockchain.TxAnchor, nil, 0, 0.01), false},
+		{"asset without a limit", limited, assetTx(blockchain.TxTransfer, "gold", 1, 0), false},
+		{"asset within its limit", withAssets, assetTx(blockchain.TxTransfer, "gold", 1000, 0.5), true},
+		{"asset amount not counted as coins", withAssets, assetTx(blockchain.TxApprove, "gold", 500, 9), true},
+		{"asset over its limit", withAssets, assetTx(blockchain.TxTransferFrom, "gold", 1001, 0), false},
+		{"asset fee over limit", withAssets, assetTx(blockchain.TxTransfer, "gold", 1, 11), false},
+		{"other asset", withAssets, assetTx(blockchain.TxTransfer, "silver", 1, 0), false},
+		{"no policy asset", Policy{}, assetTx(blockchain.TxTransfer, "gold", 1000000, 0), true},
+		{"no policy transfer", Policy{}, tx(blockchain.TxTransfer, stranger, 1000, 1), true},
+		{"no policy approve", Policy{}, tx(blockchain.TxApprove, stranger, 1000, 1), false},
+		{"no policy rotation", Policy{}, tx(blockchain.TxRotateKey, nil, 0, 0.01), false},
+		{"limited rotation", limited, tx(blockchain.TxRotateKey, nil, 0, 0.01), false},
+		{"allowed rotation", Policy{AllowRotation: true}, tx(blockchain.TxRotateKey, nil, 0, 0.01), true},
+		{"allowed rotation fee over limit", Policy{AllowRotation: true, MaxAmount: 1}, tx(blockchain.TxRotateKey, nil, 0, 2), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.CheckTransaction(tt.tx); (err == nil) != tt.ok {
+				t.Errorf("CheckTransaction() = %v, want ok=%t", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPolicyRejectsUnknownType(t *testing.T) {
+	if err := (&Policy{AllowedTypes: []string{"approve"}}).check(); err != nil {
+		t.Errorf("check() = %v for a known type", err)
+	}
+	if err := (&Policy{AllowedTypes: []string{"aprove"}}).check(); err == nil {
+		t.Error("check() accepted a misspelled type")
+	}
+	if err := (&Policy{AllowedTypes: []string{"rotate_key"}}).check(); err == nil {
+		t.Error("check() accepted rotate_key in allowed_types")
+	}
+	if err := (&Policy{AssetLimits: map[string]float64{"": 10}}).check(); err == nil {
+		t.Error("check() accepted a native coin limit in asset_limits")
+	}
+}
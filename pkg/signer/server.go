@@ -0,0 +1,278 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/consensus"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/protoconv"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+	"github.com/nguyentrinhquy1411/blockchain-go/proto"
+	"google.golang.org/grpc"
+)
+
+// Key is an unlocked key served by the daemon
+type Key struct {
+	Name   string
+	Signer wallet.Signer
+	Policy Policy
+}
+
+// Server is the reference signer daemon. It holds unlocked keys, checks every
+// request against the key's policy and logs each request and its outcome.
+type Server struct {
+	proto.UnimplementedSignerServiceServer
+	keys   map[string]*Key
+	logger *log.Logger
+
+	mutex        sync.Mutex
+	statePath    string                    // File lastProposal is kept in across restarts
+	lastProposal map[string]proposalRecord // Last proposal each key signed
+}
+
+// proposalRecord is the highest block a key signed a proposal for
+type proposalRecord struct {
+	Height    int    `json:"height"`
+	BlockHash string `json:"block_hash"`
+}
+
+// NewServer creates a signer server for the given keys. The proposals each
+// key signed are recorded in statePath, so that a restarted daemon still
+// refuses to sign a second block at a height.
+func NewServer(keys []*Key, statePath string, logger *log.Logger) (*Server, error) {
+	s := &Server{
+		keys:         make(map[string]*Key, len(keys)),
+		logger:       logger,
+		statePath:    statePath,
+		lastProposal: make(map[string]proposalRecord),
+	}
+	for _, key := range keys {
+		s.keys[key.Name] = key
+	}
+
+	data, err := os.ReadFile(statePath)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signer state: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.lastProposal); err != nil {
+		return nil, fmt.Errorf("failed to parse signer state %s: %w", statePath, err)
+	}
+	return s, nil
+}
+
+// saveState writes the signed proposals to disk and waits for them to be
+// durable, replacing the previous state file only once the new one is complete
+func (s *Server) saveState() error {
+	data, err := json.Marshal(s.lastProposal)
+	if err != nil {
+		return fmt.Errorf("failed to marshal signer state: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.statePath), filepath.Base(s.statePath)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create signer state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write signer state: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync signer state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write signer state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.statePath); err != nil {
+		return fmt.Errorf("failed to replace signer state: %w", err)
+	}
+	return nil
+}
+
+// Listen serves the signer protocol on a Unix socket that only the current
+// user can connect to, until the listener is closed
+func (s *Server) Listen(socketPath string) (*grpc.Server, net.Listener, error) {
+	// A socket left behind by a previous run blocks the listen
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	// Bind inside a private (0700) directory and restrict the socket before
+	// moving it into place, so no one else can connect in between
+	dir, err := os.MkdirTemp(filepath.Dir(socketPath), ".signer")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	boundPath := filepath.Join(dir, "signer.sock")
+
+	listener, err := net.Listen("unix", boundPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+	listener.(*net.UnixListener).SetUnlinkOnClose(false) // The socket is moved; the caller removes it
+	if err := os.Chmod(boundPath, 0600); err != nil {
+		listener.Close()
+		return nil, nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	if err := os.Rename(boundPath, socketPath); err != nil {
+		listener.Close()
+		return nil, nil, fmt.Errorf("failed to move socket to %s: %w", socketPath, err)
+	}
+
+	grpcServer := grpc.NewServer()
+	proto.RegisterSignerServiceServer(grpcServer, s)
+	return grpcServer, listener, nil
+}
+
+func (s *Server) ListKeys(ctx context.Context, req *proto.ListKeysRequest) (*proto.ListKeysResponse, error) {
+	s.logger.Printf("list-keys")
+
+	names := make([]string, 0, len(s.keys))
+	for name := range s.keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resp := &proto.ListKeysResponse{}
+	for _, name := range names {
+		key := s.keys[name]
+		resp.Keys = append(resp.Keys, &proto.SignerKey{
+			Name:      key.Name,
+			Address:   wallet.FormatAddress(key.Signer.Address()),
+			Scheme:    wallet.SchemeName(key.Signer.Scheme()),
+			PublicKey: key.Signer.PublicKey(),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) SignTransaction(ctx context.Context, req *proto.SignTransactionRequest) (*proto.NodeSignature, error) {
+	if req.Transaction == nil {
+		return nil, s.deny("sign-transaction", req.Key, "missing transaction")
+	}
+	tx, err := protoconv.TransactionFromProto(req.Transaction)
+	if err != nil {
+		return nil, s.deny("sign-transaction", req.Key, err.Error())
+	}
+	asset := tx.Asset
+	if asset == blockchain.NativeAsset {
+		asset = blockchain.NativeAssetSymbol
+	}
+	summary := fmt.Sprintf("type=%s to=%s amount=%.8f asset=%s fee=%.8f nonce=%d",
+		txTypeName(tx.Type), wallet.FormatAddress(tx.Receiver), tx.Amount, asset, tx.Fee, tx.Nonce)
+
+	key, ok := s.keys[req.Key]
+	if !ok {
+		return nil, s.deny("sign-transaction", req.Key, "unknown key")
+	}
+	if !wallet.Address(tx.Sender).Equal(key.Signer.Address()) {
+		return nil, s.deny("sign-transaction", req.Key, fmt.Sprintf("sender %s is not the key's address", wallet.FormatAddress(tx.Sender)))
+	}
+	if err := key.Policy.CheckTransaction(tx); err != nil {
+		return nil, s.deny("sign-transaction", req.Key, fmt.Sprintf("%s: %v", summary, err))
+	}
+
+	if err := wallet.SignTransactionWith(tx, key.Signer); err != nil {
+		return nil, s.deny("sign-transaction", req.Key, err.Error())
+	}
+	s.logger.Printf("sign-transaction key=%s %s: signed", req.Key, summary)
+	return &proto.NodeSignature{
+		Scheme:    string(tx.Scheme),
+		PublicKey: tx.PublicKey,
+		Signature: tx.Signature,
+	}, nil
+}
+
+func (s *Server) SignProposal(ctx context.Context, req *proto.SignProposalRequest) (*proto.NodeSignature, error) {
+	summary := fmt.Sprintf("height=%d block=%s", req.Height, req.BlockHash)
+	key, err := s.consensusKey("sign-proposal", req.Key, summary)
+	if err != nil {
+		return nil, err
+	}
+
+	// Never sign two blocks for the same height. The same block may be
+	// signed again, since a proposal that timed out is proposed again.
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	last, signed := s.lastProposal[req.Key]
+	if signed && int(req.Height) < last.Height {
+		return nil, s.deny("sign-proposal", req.Key, fmt.Sprintf("%s: already signed a proposal at height %d", summary, last.Height))
+	}
+	if signed && int(req.Height) == last.Height && req.BlockHash != last.BlockHash {
+		return nil, s.deny("sign-proposal", req.Key, fmt.Sprintf("%s: already signed block %s at this height", summary, last.BlockHash))
+	}
+	if !signed || int(req.Height) > last.Height {
+		// The record must be on disk before the signature leaves the daemon
+		s.lastProposal[req.Key] = proposalRecord{Height: int(req.Height), BlockHash: req.BlockHash}
+		if err := s.saveState(); err != nil {
+			if signed {
+				s.lastProposal[req.Key] = last
+			} else {
+				delete(s.lastProposal, req.Key)
+			}
+			return nil, s.deny("sign-proposal", req.Key, fmt.Sprintf("%s: %v", summary, err))
+		}
+	}
+
+	return s.signDigest("sign-proposal", key, summary, consensus.ProposalDigest(int(req.Height), req.BlockHash))
+}
+
+func (s *Server) SignVote(ctx context.Context, req *proto.SignVoteRequest) (*proto.NodeSignature, error) {
+	summary := fmt.Sprintf("block=%s voter=%s approve=%t", req.BlockHash, req.VoterId, req.Approve)
+	key, err := s.consensusKey("sign-vote", req.Key, summary)
+	if err != nil {
+		return nil, err
+	}
+	return s.signDigest("sign-vote", key, summary, consensus.VoteDigest(req.BlockHash, req.VoterId, req.Approve))
+}
+
+// consensusKey returns a key that is allowed to sign proposals and votes
+func (s *Server) consensusKey(op, name, summary string) (*Key, error) {
+	key, ok := s.keys[name]
+	if !ok {
+		return nil, s.deny(op, name, summary+": unknown key")
+	}
+	if !key.Policy.Consensus {
+		return nil, s.deny(op, name, summary+": key may not sign consensus messages")
+	}
+	return key, nil
+}
+
+func (s *Server) signDigest(op string, key *Key, summary string, digest []byte) (*proto.NodeSignature, error) {
+	sig, err := key.Signer.Sign(digest)
+	if err != nil {
+		return nil, s.deny(op, key.Name, fmt.Sprintf("%s: %v", summary, err))
+	}
+	s.logger.Printf("%s key=%s %s: signed", op, key.Name, summary)
+	return &proto.NodeSignature{
+		Scheme:    string(key.Signer.Scheme()),
+		PublicKey: key.Signer.PublicKey(),
+		Signature: sig,
+	}, nil
+}
+
+// deny logs a refused request and returns the error sent to the client
+func (s *Server) deny(op, name, reason string) error {
+	s.logger.Printf("%s key=%s: DENIED: %s", op, name, reason)
+	return fmt.Errorf("signer refused: %s", reason)
+}
+
+func txTypeName(txType blockchain.TxType) string {
+	if txType == blockchain.TxTransfer {
+		return "transfer"
+	}
+	return string(txType)
+}
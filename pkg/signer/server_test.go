@@ -0,0 +1,105 @@
+package signer
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+	"github.com/nguyentrinhquy1411/blockchain-go/proto"
+)
+
+func TestSignProposalOncePerHeight(t *testing.T) {
+	key, err := wallet.GenerateSigner(blockchain.SchemeP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []*Key{{Name: "validator", Signer: key, Policy: Policy{Consensus: true}}}
+	statePath := filepath.Join(t.TempDir(), "signer_state.json")
+	logger := log.New(io.Discard, "", 0)
+
+	newServer := func() *Server {
+		s, err := NewServer(keys, statePath, logger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	server := newServer()
+
+	tests := []struct {
+		name    string
+		restart bool
+		height  int64
+		hash    string
+		ok      bool
+	}{
+		{"first proposal", false, 5, "aa", true},
+		{"same block after a timeout", false, 5, "aa", true},
+		{"other block at the same height", false, 5, "bb", false},
+		{"lower height", false, 4, "cc", false},
+		{"next height", false, 6, "dd", true},
+		{"other block after a restart", true, 6, "ee", false},
+		{"same block after a restart", true, 6, "dd", true},
+		{"next height after a restart", true, 7, "ff", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.restart {
+				server = newServer()
+			}
+			_, err := server.SignProposal(context.Background(), &proto.SignProposalRequest{
+				Key:       "validator",
+				Height:    tt.height,
+				BlockHash: tt.hash,
+			})
+			if (err == nil) != tt.ok {
+				t.Errorf("SignProposal(%d, %s) = %v, want ok=%t", tt.height, tt.hash, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestListenRestrictsSocket(t *testing.T) {
+	key, err := wallet.GenerateSigner(blockchain.SchemeEd25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	server, err := NewServer([]*Key{{Name: "treasury", Signer: key}}, filepath.Join(dir, "signer_state.json"), log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	socketPath := filepath.Join(dir, "signer.sock")
+	grpcServer, listener, err := server.Listen(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	info, err := os.Stat(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSocket == 0 || info.Mode().Perm() != 0600 {
+		t.Errorf("socket mode = %v, want a socket with 0600", info.Mode())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("directory holds %d entries after Listen, want only the socket", len(entries))
+	}
+
+	client, err := Dial(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if keys, err := client.Keys(context.Background()); err != nil || len(keys) != 1 {
+		t.Errorf("Keys() over the socket = %v, %v, want the one key", keys, err)
+	}
+}
@@ -0,0 +1,92 @@
+package storage
+
+import "fmt"
+
+// Backend is the ordered key-value database under a Store. LevelDB keeps it
+// on disk (NewLevelDB); the memory backend (NewMemory) keeps it in process
+// for tests, simulations and throwaway nodes.
+type Backend interface {
+	Reader
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	// Write applies every operation of a batch atomically
+	Write(batch *Batch) error
+	// Snapshot returns a consistent read-only view of the current contents
+	Snapshot() (Snapshot, error)
+	Close() error
+}
+
+// Reader is the read side of a backend or snapshot
+type Reader interface {
+	// Get returns the value of a key, or ErrNotFound
+	Get(key []byte) ([]byte, error)
+	Has(key []byte) (bool, error)
+	// NewIterator walks the keys starting with prefix in ascending order
+	NewIterator(prefix []byte) Iterator
+}
+
+// Snapshot is a frozen view of a backend; it must be released after use
+type Snapshot interface {
+	Reader
+	Release()
+}
+
+// Iterator walks key/value pairs in key order. Key and Value are only valid
+// until the iterator moves again.
+type Iterator interface {
+	Next() bool
+	Prev() bool
+	// Seek moves to the first key at or after key
+	Seek(key []byte) bool
+	// Last moves to the last key
+	Last() bool
+	Key() []byte
+	Value() []byte
+	Release()
+	Error() error
+}
+
+// Batch collects writes that a backend applies all at once
+type Batch struct {
+	ops []batchOp
+}
+
+type batchOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// Put records a write of key
+func (b *Batch) Put(key, value []byte) {
+	b.ops = append(b.ops, batchOp{key: key, value: value})
+}
+
+// Delete records a removal of key
+func (b *Batch) Delete(key []byte) {
+	b.ops = append(b.ops, batchOp{key: key, delete: true})
+}
+
+// Len returns the number of operations in the batch
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// Backend kinds accepted by Open
+const (
+	KindLevelDB = "leveldb"
+	KindMemory  = "memory"
+)
+
+// Open opens a node's chain store: a LevelDB database at path, or with
+// KindMemory an in-memory store that is gone when the process exits
+func Open(kind, path string) (*Store, error) {
+	switch kind {
+	case "", KindLevelDB:
+		return NewLevelDB(path)
+	case KindMemory:
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q (want %s or %s)", kind, KindLevelDB, KindMemory)
+	}
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// addressTxPrefix starts the address history keys,
+// addrtx_<address hex>_<height>_<position>. Heights and positions are zero
+// padded so that the keys of an address sort in chain order.
+const addressTxPrefix = "addrtx_"
+
+func addressHistoryPrefix(address []byte) string {
+	return addressTxPrefix + hex.EncodeToString(address) + "_"
+}
+
+// historyCursor is the part of an address history key after the address
+func historyCursor(height, position int) string {
+	return fmt.Sprintf("%010d_%06d", height, position)
+}
+
+// indexAddresses adds the history entries of a committed transaction to a
+// batch, one per address that sent or received it
+func indexAddresses(batch *Batch, tx *blockchain.Transaction, hash []byte, height, position int) error {
+	sent, received := tx.Parties()
+	entries := make(map[string]*blockchain.AddressTx)
+	var order []string // Addresses in the order found, so batches are deterministic
+	entry := func(address []byte) *blockchain.AddressTx {
+		if e, ok := entries[string(address)]; ok {
+			return e
+		}
+		e := &blockchain.AddressTx{Hash: hash, Height: height, Position: position}
+		entries[string(address)] = e
+		order = append(order, string(address))
+		return e
+	}
+	for _, address := range sent {
+		entry(address).Sent = true
+	}
+	for _, address := range received {
+		entry(address).Received = true
+	}
+
+	for _, address := range order {
+		data, err := json.Marshal(entries[address])
+		if err != nil {
+			return fmt.Errorf("failed to marshal address history entry: %w", err)
+		}
+		batch.Put([]byte(addressHistoryPrefix([]byte(address))+historyCursor(height, position)), data)
+	}
+	return nil
+}
+
+// AddressHistory returns a page of the transactions an address sent or
+// received, walking its index keys from the query's cursor
+func (s *Store) AddressHistory(address []byte, query blockchain.HistoryQuery) (*blockchain.HistoryPage, error) {
+	if query.Limit <= 0 {
+		return nil, fmt.Errorf("history page limit must be positive")
+	}
+	prefix := addressHistoryPrefix(address)
+	var cursor []byte
+	if query.Cursor != "" {
+		var height, position int
+		if _, err := fmt.Sscanf(query.Cursor, "%d_%d", &height, &position); err != nil || historyCursor(height, position) != query.Cursor {
+			return nil, fmt.Errorf("invalid history cursor %q", query.Cursor)
+		}
+		cursor = []byte(prefix + query.Cursor)
+	}
+
+	iter := s.db.NewIterator([]byte(prefix))
+	defer iter.Release()
+
+	// Position the iterator on the first entry after the cursor, in the
+	// direction of the walk
+	step := iter.Prev
+	if query.OldestFirst {
+		step = iter.Next
+	}
+	var ok bool
+	switch {
+	case query.OldestFirst && cursor == nil:
+		ok = iter.Next()
+	case query.OldestFirst:
+		ok = iter.Seek(cursor)
+		if ok && bytes.Equal(iter.Key(), cursor) {
+			ok = iter.Next()
+		}
+	case cursor == nil:
+		ok = iter.Last()
+	default:
+		if ok = iter.Seek(cursor); !ok {
+			ok = iter.Last()
+		}
+		for ok && bytes.Compare(iter.Key(), cursor) >= 0 {
+			ok = iter.Prev()
+		}
+	}
+
+	page := &blockchain.HistoryPage{}
+	for ; ok; ok = step() {
+		var entry blockchain.AddressTx
+		if err := json.Unmarshal(iter.Value(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid address history entry %s: %w", iter.Key(), err)
+		}
+		if !entry.Matches(query.Direction) {
+			continue
+		}
+		if len(page.Entries) == query.Limit {
+			// Another entry follows, so the page gets a cursor
+			last := page.Entries[len(page.Entries)-1]
+			page.Next = historyCursor(last.Height, last.Position)
+			break
+		}
+		page.Entries = append(page.Entries, entry)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read address history: %w", err)
+	}
+	return page, nil
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// historyStore holds a chain where testAddress(7) has five history entries,
+// oldest first: 0/0 received, 1/0 sent, 1/1 received, 3/0 both and 4/0 sent
+func historyStore(t *testing.T) *Store {
+	t.Helper()
+	a, b, c := testAddress(7), testAddress(8), testAddress(9)
+	transfer := func(sender, receiver []byte) *blockchain.Transaction {
+		return &blockchain.Transaction{Sender: sender, Receiver: receiver, Amount: 1, Timestamp: 1700000000}
+	}
+	blockTxs := [][]*blockchain.Transaction{
+		{transfer(blockchain.GenesisSender, a)},
+		{transfer(a, b), transfer(b, a)},
+		{transfer(b, c)},
+		{transfer(a, a)},
+		{transfer(a, c)},
+	}
+
+	s := NewMemory()
+	var prevHash []byte
+	for height, txs := range blockTxs {
+		block := blockchain.NewBlock(height, txs, prevHash)
+		if err := s.WriteBlock(block, nil); err != nil {
+			t.Fatal(err)
+		}
+		prevHash = block.CurrentBlockHash
+	}
+	return s
+}
+
+// walkHistory follows a query's cursors to the end and returns each entry
+// as height/position along with the number of pages read
+func walkHistory(t *testing.T, s *Store, address []byte, query blockchain.HistoryQuery) ([]string, int) {
+	t.Helper()
+	var entries []string
+	for pages := 1; ; pages++ {
+		page, err := s.AddressHistory(address, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Entries) > query.Limit {
+			t.Fatalf("page of %d entries, limit %d", len(page.Entries), query.Limit)
+		}
+		for _, entry := range page.Entries {
+			entries = append(entries, fmt.Sprintf("%d/%d", entry.Height, entry.Position))
+		}
+		if page.Next == "" {
+			return entries, pages
+		}
+		if len(page.Entries) != query.Limit {
+			t.Fatalf("short page of %d entries has a next cursor", len(page.Entries))
+		}
+		query.Cursor = page.Next
+	}
+}
+
+func TestAddressHistoryPaging(t *testing.T) {
+	s := historyStore(t)
+	newest := []string{"4/0", "3/0", "1/1", "1/0", "0/0"}
+	oldest := []string{"0/0", "1/0", "1/1", "3/0", "4/0"}
+
+	tests := []struct {
+		name      string
+		address   []byte
+		query     blockchain.HistoryQuery
+		want      []string
+		wantPages int
+	}{
+		{"one per page", testAddress(7), blockchain.HistoryQuery{Limit: 1}, newest, 5},
+		{"uneven pages", testAddress(7), blockchain.HistoryQuery{Limit: 2}, newest, 3},
+		{"limit equals count", testAddress(7), blockchain.HistoryQuery{Limit: 5}, newest, 1},
+		{"limit above count", testAddress(7), blockchain.HistoryQuery{Limit: 6}, newest, 1},
+		{"oldest first", testAddress(7), blockchain.HistoryQuery{Limit: 2, OldestFirst: true}, oldest, 3},
+		{"oldest first limit equals count", testAddress(7), blockchain.HistoryQuery{Limit: 5, OldestFirst: true}, oldest, 1},
+		{"sent", testAddress(7), blockchain.HistoryQuery{Direction: blockchain.DirectionSent, Limit: 2}, []string{"4/0", "3/0", "1/0"}, 2},
+		// A received entry follows the last sent one, yet no page is left
+		{"sent limit equals matches", testAddress(7), blockchain.HistoryQuery{Direction: blockchain.DirectionSent, Limit: 3}, []string{"4/0", "3/0", "1/0"}, 1},
+		{"received oldest first", testAddress(7), blockchain.HistoryQuery{Direction: blockchain.DirectionReceived, Limit: 1, OldestFirst: true}, []string{"0/0", "1/1", "3/0"}, 3},
+		{"cursor before the first entry", testAddress(7), blockchain.HistoryQuery{Cursor: historyCursor(9, 0), Limit: 5}, newest, 1},
+		{"cursor at the last entry", testAddress(7), blockchain.HistoryQuery{Cursor: historyCursor(4, 0), Limit: 5, OldestFirst: true}, nil, 1},
+		{"cursor between entries", testAddress(7), blockchain.HistoryQuery{Cursor: historyCursor(2, 0), Limit: 5}, []string{"1/1", "1/0", "0/0"}, 1},
+		{"uninvolved address", testAddress(10), blockchain.HistoryQuery{Limit: 5}, nil, 1},
+		{"address prefix of another", testAddress(7)[:4], blockchain.HistoryQuery{Limit: 5}, nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pages := walkHistory(t, s, tt.address, tt.query)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("entries = %v, want %v", got, tt.want)
+			}
+			if pages != tt.wantPages {
+				t.Errorf("%d pages, want %d", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestAddressHistoryRejectsQuery(t *testing.T) {
+	s := historyStore(t)
+	tests := []struct {
+		name  string
+		query blockchain.HistoryQuery
+		ok    bool
+	}{
+		{"padded cursor", blockchain.HistoryQuery{Cursor: "0000000003_000000", Limit: 1}, true},
+		{"zero limit", blockchain.HistoryQuery{Limit: 0}, false},
+		{"negative limit", blockchain.HistoryQuery{Limit: -1}, false},
+		{"unpadded cursor", blockchain.HistoryQuery{Cursor: "3_0", Limit: 1}, false},
+		{"garbage cursor", blockchain.HistoryQuery{Cursor: "abc", Limit: 1}, false},
+		{"trailing text", blockchain.HistoryQuery{Cursor: "0000000003_000000x", Limit: 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.AddressHistory(testAddress(7), tt.query); (err == nil) != tt.ok {
+				t.Errorf("AddressHistory() = %v, want ok=%t", err, tt.ok)
+			}
+		})
+	}
+}
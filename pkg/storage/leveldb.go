@@ -1,112 +1,105 @@
 package storage
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strconv"
 
-	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
-type BlockStorage struct {
+// levelDB is the on-disk backend
+type levelDB struct {
 	db *leveldb.DB
 }
 
-func NewBlockStorage(dbPath string) (*BlockStorage, error) {
+// NewLevelDB opens the chain store kept in a LevelDB database at dbPath
+func NewLevelDB(dbPath string) (*Store, error) {
 	db, err := leveldb.OpenFile(dbPath, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open leveldb: %w", err)
 	}
-
-	return &BlockStorage{db: db}, nil
-}
-
-func (bs *BlockStorage) Close() error {
-	return bs.db.Close()
+	return NewStore(&levelDB{db: db})
 }
 
-func (bs *BlockStorage) SaveBlock(block *blockchain.Block) error {
-	blockBytes, err := json.Marshal(block)
+// OpenUnmigrated opens the LevelDB chain store at dbPath as it is, without
+// migrating or repairing it, to inspect and run its migrations
+func OpenUnmigrated(dbPath string) (*Store, error) {
+	db, err := leveldb.OpenFile(dbPath, nil)
 	if err != nil {
-		return fmt.Errorf("failed to marshal block: %w", err)
+		return nil, fmt.Errorf("failed to open leveldb: %w", err)
 	}
-
-	return bs.db.Put(block.CurrentBlockHash, blockBytes, nil)
+	return &Store{db: &levelDB{db: db}}, nil
 }
 
-func (bs *BlockStorage) GetBlock(hash []byte) (*blockchain.Block, error) {
-	blockBytes, err := bs.db.Get(hash, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get block: %w", err)
-	}
-
-	var block blockchain.Block
-	if err := json.Unmarshal(blockBytes, &block); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal block: %w", err)
-	}
-
-	return &block, nil
+func (l *levelDB) Get(key []byte) ([]byte, error) {
+	return levelDBGet(l.db.Get(key, nil))
 }
 
-func (bs *BlockStorage) StoreBlockByIndex(block *blockchain.Block) error {
-	key := "height_" + strconv.Itoa(block.Index)
-	return bs.db.Put([]byte(key), block.CurrentBlockHash, nil)
+func (l *levelDB) Has(key []byte) (bool, error) {
+	return l.db.Has(key, nil)
 }
 
-func (bs *BlockStorage) GetBlockByIndex(index int) (*blockchain.Block, error) {
-	key := "height_" + strconv.Itoa(index)
-	hash, err := bs.db.Get([]byte(key), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get block hash by index: %w", err)
-	}
+func (l *levelDB) NewIterator(prefix []byte) Iterator {
+	return l.db.NewIterator(util.BytesPrefix(prefix), nil)
+}
 
-	return bs.GetBlock(hash)
+func (l *levelDB) Put(key, value []byte) error {
+	return l.db.Put(key, value, nil)
 }
 
-func (bs *BlockStorage) GetLatestIndex() (int, error) {
-	iter := bs.db.NewIterator(nil, nil)
-	defer iter.Release()
+func (l *levelDB) Delete(key []byte) error {
+	return l.db.Delete(key, nil)
+}
 
-	latestIndex := -1
-	for iter.Next() {
-		key := string(iter.Key())
-		if len(key) > 7 && key[:7] == "height_" {
-			index, err := strconv.Atoi(key[7:])
-			if err == nil && index > latestIndex {
-				latestIndex = index
-			}
+func (l *levelDB) Write(batch *Batch) error {
+	b := new(leveldb.Batch)
+	for _, op := range batch.ops {
+		if op.delete {
+			b.Delete(op.key)
+		} else {
+			b.Put(op.key, op.value)
 		}
 	}
+	return l.db.Write(b, nil)
+}
 
-	if err := iter.Error(); err != nil {
-		return -1, fmt.Errorf("iterator error: %w", err)
+func (l *levelDB) Snapshot() (Snapshot, error) {
+	snap, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
 	}
+	return &levelDBSnapshot{snap: snap}, nil
+}
 
-	return latestIndex, nil
+func (l *levelDB) Close() error {
+	return l.db.Close()
 }
 
-type LevelDB struct {
-	db *leveldb.DB
+type levelDBSnapshot struct {
+	snap *leveldb.Snapshot
 }
 
-func NewLevelDB(dbPath string) (*LevelDB, error) {
-	db, err := leveldb.OpenFile(dbPath, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open leveldb: %w", err)
-	}
+func (s *levelDBSnapshot) Get(key []byte) ([]byte, error) {
+	return levelDBGet(s.snap.Get(key, nil))
+}
 
-	return &LevelDB{db: db}, nil
+func (s *levelDBSnapshot) Has(key []byte) (bool, error) {
+	return s.snap.Has(key, nil)
 }
 
-func (ldb *LevelDB) Get(key string) ([]byte, error) {
-	return ldb.db.Get([]byte(key), nil)
+func (s *levelDBSnapshot) NewIterator(prefix []byte) Iterator {
+	return s.snap.NewIterator(util.BytesPrefix(prefix), nil)
 }
 
-func (ldb *LevelDB) Put(key string, value []byte) error {
-	return ldb.db.Put([]byte(key), value, nil)
+func (s *levelDBSnapshot) Release() {
+	s.snap.Release()
 }
 
-func (ldb *LevelDB) Close() error {
-	return ldb.db.Close()
+// levelDBGet maps LevelDB's not-found error to ErrNotFound
+func levelDBGet(value []byte, err error) ([]byte, error) {
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return value, err
 }
@@ -0,0 +1,232 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// errClosed is returned by a memory backend after Close
+var errClosed = errors.New("storage is closed")
+
+// errReleased is returned by a memory snapshot after Release
+var errReleased = errors.New("snapshot is released")
+
+// memory is the in-process backend: a map guarded by a lock. Iterators and
+// snapshots copy what they cover, so they are never affected by later writes.
+type memory struct {
+	mutex sync.RWMutex
+	data  map[string][]byte
+}
+
+// NewMemory returns an empty chain store that lives only in memory
+func NewMemory() *Store {
+	store, _ := NewStore(newMemory()) // An empty store needs no migration or repair
+	return store
+}
+
+func newMemory() *memory {
+	return &memory{data: make(map[string][]byte)}
+}
+
+func (m *memory) Get(key []byte) ([]byte, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.data == nil {
+		return nil, errClosed
+	}
+	return memoryGet(m.data, key)
+}
+
+func (m *memory) Has(key []byte) (bool, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.data == nil {
+		return false, errClosed
+	}
+	_, ok := m.data[string(key)]
+	return ok, nil
+}
+
+func (m *memory) NewIterator(prefix []byte) Iterator {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.data == nil {
+		return &memoryIterator{pos: -1, err: errClosed}
+	}
+	return newMemoryIterator(m.data, prefix)
+}
+
+func (m *memory) Put(key, value []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.data == nil {
+		return errClosed
+	}
+	m.data[string(key)] = bytes.Clone(value)
+	return nil
+}
+
+func (m *memory) Delete(key []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.data == nil {
+		return errClosed
+	}
+	delete(m.data, string(key))
+	return nil
+}
+
+func (m *memory) Write(batch *Batch) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.data == nil {
+		return errClosed
+	}
+	for _, op := range batch.ops {
+		if op.delete {
+			delete(m.data, string(op.key))
+		} else {
+			m.data[string(op.key)] = bytes.Clone(op.value)
+		}
+	}
+	return nil
+}
+
+func (m *memory) Snapshot() (Snapshot, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if m.data == nil {
+		return nil, errClosed
+	}
+	data := make(map[string][]byte, len(m.data))
+	for key, value := range m.data {
+		data[key] = value // Values are never modified in place
+	}
+	return &memorySnapshot{data: data}, nil
+}
+
+func (m *memory) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.data = nil
+	return nil
+}
+
+type memorySnapshot struct {
+	data map[string][]byte
+}
+
+func (s *memorySnapshot) Get(key []byte) ([]byte, error) {
+	if s.data == nil {
+		return nil, errReleased
+	}
+	return memoryGet(s.data, key)
+}
+
+func (s *memorySnapshot) Has(key []byte) (bool, error) {
+	if s.data == nil {
+		return false, errReleased
+	}
+	_, ok := s.data[string(key)]
+	return ok, nil
+}
+
+func (s *memorySnapshot) NewIterator(prefix []byte) Iterator {
+	if s.data == nil {
+		return &memoryIterator{pos: -1, err: errReleased}
+	}
+	return newMemoryIterator(s.data, prefix)
+}
+
+func (s *memorySnapshot) Release() {
+	s.data = nil
+}
+
+func memoryGet(data map[string][]byte, key []byte) ([]byte, error) {
+	value, ok := data[string(key)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return bytes.Clone(value), nil
+}
+
+// memoryIterator walks the pairs matching a prefix, sorted when the
+// iterator was created
+type memoryIterator struct {
+	keys   []string
+	values [][]byte
+	pos    int
+	err    error
+}
+
+func newMemoryIterator(data map[string][]byte, prefix []byte) *memoryIterator {
+	it := &memoryIterator{pos: -1}
+	for key := range data {
+		if strings.HasPrefix(key, string(prefix)) {
+			it.keys = append(it.keys, key)
+		}
+	}
+	sort.Strings(it.keys)
+	it.values = make([][]byte, len(it.keys))
+	for i, key := range it.keys {
+		it.values[i] = data[key]
+	}
+	return it
+}
+
+func (it *memoryIterator) Next() bool {
+	if it.err != nil || it.pos >= len(it.keys) {
+		return false
+	}
+	it.pos++
+	return it.pos < len(it.keys)
+}
+
+func (it *memoryIterator) Prev() bool {
+	if it.err != nil || it.pos < 0 {
+		return false
+	}
+	it.pos--
+	return it.pos >= 0
+}
+
+func (it *memoryIterator) Seek(key []byte) bool {
+	if it.err != nil {
+		return false
+	}
+	it.pos = sort.SearchStrings(it.keys, string(key))
+	return it.pos < len(it.keys)
+}
+
+func (it *memoryIterator) Last() bool {
+	if it.err != nil {
+		return false
+	}
+	it.pos = len(it.keys) - 1
+	return it.pos >= 0
+}
+
+func (it *memoryIterator) Key() []byte {
+	if it.pos < 0 || it.pos >= len(it.keys) {
+		return nil
+	}
+	return []byte(it.keys[it.pos])
+}
+
+func (it *memoryIterator) Value() []byte {
+	if it.pos < 0 || it.pos >= len(it.keys) {
+		return nil
+	}
+	return it.values[it.pos]
+}
+
+func (it *memoryIterator) Release() {
+	it.keys, it.values = nil, nil
+}
+
+func (it *memoryIterator) Error() error {
+	return it.err
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// readKeys returns the keys an iterator walks
+func readKeys(t *testing.T, iter Iterator) []string {
+	t.Helper()
+	defer iter.Release()
+	var keys []string
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestMemoryBatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		batch func(*Batch)
+		want  []string
+	}{
+		{"puts", func(b *Batch) { b.Put([]byte("b"), nil); b.Put([]byte("a"), nil) }, []string{"a", "b", "kept"}},
+		{"put then delete", func(b *Batch) { b.Put([]byte("a"), nil); b.Delete([]byte("a")) }, []string{"kept"}},
+		{"delete then put", func(b *Batch) { b.Delete([]byte("kept")); b.Put([]byte("kept"), nil) }, []string{"kept"}},
+		{"delete missing key", func(b *Batch) { b.Delete([]byte("a")) }, []string{"kept"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMemory()
+			if err := m.Put([]byte("kept"), []byte("1")); err != nil {
+				t.Fatal(err)
+			}
+			batch := new(Batch)
+			tt.batch(batch)
+			if err := m.Write(batch); err != nil {
+				t.Fatal(err)
+			}
+			if got := readKeys(t, m.NewIterator(nil)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keys = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryIteratorPrefix(t *testing.T) {
+	m := newMemory()
+	for _, key := range []string{"block_2", "blockhash_aa", "block_10", "tip", "block_1"} {
+		if err := m.Put([]byte(key), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"block_1", "block_10", "block_2"}
+	if got := readKeys(t, m.NewIterator([]byte(blockKeyPrefix))); !reflect.DeepEqual(got, want) {
+		t.Errorf("keys = %v, want %v", got, want)
+	}
+}
+
+func TestMemorySnapshotIsolation(t *testing.T) {
+	m := newMemory()
+	if err := m.Put([]byte("a"), []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := m.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer snap.Release()
+
+	batch := new(Batch)
+	batch.Put([]byte("a"), []byte("new"))
+	batch.Put([]byte("b"), []byte("new"))
+	if err := m.Write(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, err := snap.Get([]byte("a")); err != nil || string(value) != "old" {
+		t.Errorf("snapshot Get(a) = %q, %v, want old", value, err)
+	}
+	if ok, _ := snap.Has([]byte("b")); ok {
+		t.Error("snapshot sees a key written after it was taken")
+	}
+	if got := readKeys(t, snap.NewIterator(nil)); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("snapshot keys = %v, want [a]", got)
+	}
+}
+
+func TestMemoryClosed(t *testing.T) {
+	m := newMemory()
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Get([]byte("a")); !errors.Is(err, errClosed) {
+		t.Errorf("Get() = %v, want errClosed", err)
+	}
+	if _, err := m.Has([]byte("a")); !errors.Is(err, errClosed) {
+		t.Errorf("Has() = %v, want errClosed", err)
+	}
+	if err := m.Put([]byte("a"), nil); !errors.Is(err, errClosed) {
+		t.Errorf("Put() = %v, want errClosed", err)
+	}
+	if err := m.Write(new(Batch)); !errors.Is(err, errClosed) {
+		t.Errorf("Write() = %v, want errClosed", err)
+	}
+	if _, err := m.Snapshot(); !errors.Is(err, errClosed) {
+		t.Errorf("Snapshot() = %v, want errClosed", err)
+	}
+	iter := m.NewIterator(nil)
+	if iter.Next() || !errors.Is(iter.Error(), errClosed) {
+		t.Errorf("iterator of a closed backend: Error() = %v, want errClosed", iter.Error())
+	}
+}
+
+func TestMemorySnapshotReleased(t *testing.T) {
+	m := newMemory()
+	if err := m.Put([]byte("a"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := m.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap.Release()
+	if _, err := snap.Get([]byte("a")); !errors.Is(err, errReleased) {
+		t.Errorf("Get() = %v, want errReleased", err)
+	}
+	if _, err := snap.Has([]byte("a")); !errors.Is(err, errReleased) {
+		t.Errorf("Has() = %v, want errReleased", err)
+	}
+	iter := snap.NewIterator(nil)
+	if iter.Next() || !errors.Is(iter.Error(), errReleased) {
+		t.Errorf("iterator of a released snapshot: Error() = %v, want errReleased", iter.Error())
+	}
+}
+
+func TestMemoryIteratorSeek(t *testing.T) {
+	m := newMemory()
+	for _, key := range []string{"k1", "k3", "k5"} {
+		if err := m.Put([]byte(key), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		move func(Iterator) bool
+		ok   bool
+		want string
+	}{
+		{"seek existing key", func(it Iterator) bool { return it.Seek([]byte("k3")) }, true, "k3"},
+		{"seek between keys", func(it Iterator) bool { return it.Seek([]byte("k4")) }, true, "k5"},
+		{"seek past the end", func(it Iterator) bool { return it.Seek([]byte("k6")) }, false, ""},
+		{"last", func(it Iterator) bool { return it.Last() }, true, "k5"},
+		{"prev from last", func(it Iterator) bool { return it.Last() && it.Prev() }, true, "k3"},
+		{"prev from first", func(it Iterator) bool { return it.Next() && it.Prev() }, false, ""},
+		{"next after seek", func(it Iterator) bool { return it.Seek([]byte("k1")) && it.Next() }, true, "k3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iter := m.NewIterator(nil)
+			defer iter.Release()
+			if ok := tt.move(iter); ok != tt.ok || string(iter.Key()) != tt.want {
+				t.Errorf("moved = %t at %q, want %t at %q", ok, iter.Key(), tt.ok, tt.want)
+			}
+		})
+	}
+}
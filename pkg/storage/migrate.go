@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+// schemaVersionKey holds the schema version of the store: the last migration
+// applied to it. Stores written before it existed count as version 0.
+const schemaVersionKey = "schema_version"
+
+// Keys of layouts that migrations convert or drop
+const (
+	legacyHeightPrefix = "height_"       // height_<n> -> raw block hash, in the old single-node store
+	legacyIndexVersion = "index_version" // Index marker that schema_version replaced
+)
+
+// Migration upgrades a store from schema Version-1 to Version
+type Migration struct {
+	Version int
+	Name    string
+	// Run applies the migration and returns the number of blocks it changed;
+	// with dryRun it only counts them. It must be safe to run again after an
+	// interruption, as the schema version only moves once it returns.
+	Run func(s *Store, dryRun bool) (int, error)
+}
+
+// migrations lists every schema change in the order they are applied
+var migrations = []Migration{
+	{Version: 1, Name: "move blocks stored by hash to height keys", Run: migrateLegacyBlocks},
+	{Version: 2, Name: "rebuild tip, hash, transaction and address indexes", Run: migrateIndexes},
+}
+
+// LatestSchema returns the schema version this code reads and writes
+func LatestSchema() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersion returns the schema version recorded in the store. A store
+// without a record is at version 0, or at the latest one if it is empty.
+func (s *Store) SchemaVersion() (int, error) {
+	data, err := s.db.Get([]byte(schemaVersionKey))
+	if errors.Is(err, ErrNotFound) {
+		empty, err := s.isEmpty()
+		if err != nil || !empty {
+			return 0, err
+		}
+		return LatestSchema(), nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	version, err := strconv.Atoi(string(data))
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version %q: %w", data, err)
+	}
+	return version, nil
+}
+
+// PendingMigrations returns the migrations the store still needs, in order
+func (s *Store) PendingMigrations() ([]Migration, error) {
+	version, err := s.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if version > LatestSchema() {
+		return nil, fmt.Errorf("store schema %d is newer than this version supports (%d)", version, LatestSchema())
+	}
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies the pending migrations in order, recording the schema
+// version after each so that an interrupted run resumes with the migration
+// it stopped in. With dryRun nothing is written and the block counts are
+// those of the store as it is now. report, if set, is called after each one.
+func (s *Store) Migrate(dryRun bool, report func(m Migration, blocks int)) error {
+	pending, err := s.PendingMigrations()
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		for _, m := range pending {
+			blocks, err := m.Run(s, true)
+			if err != nil {
+				return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+			}
+			if report != nil {
+				report(m, blocks)
+			}
+		}
+		return nil
+	}
+
+	if len(pending) == 0 {
+		// New stores start at the latest schema
+		if ok, err := s.db.Has([]byte(schemaVersionKey)); err != nil || ok {
+			return err
+		}
+		return s.setSchemaVersion(LatestSchema())
+	}
+	for _, m := range pending {
+		blocks, err := m.Run(s, false)
+		if err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		if err := s.setSchemaVersion(m.Version); err != nil {
+			return err
+		}
+		if report != nil {
+			report(m, blocks)
+		}
+	}
+	return nil
+}
+
+// logMigration reports a migration applied while opening a store
+func logMigration(m Migration, blocks int) {
+	log.Printf("Storage migrated to schema %d (%s): %d block(s)", m.Version, m.Name, blocks)
+}
+
+func (s *Store) setSchemaVersion(version int) error {
+	if err := s.db.Put([]byte(schemaVersionKey), []byte(strconv.Itoa(version))); err != nil {
+		return fmt.Errorf("failed to record schema version %d: %w", version, err)
+	}
+	return nil
+}
+
+// isEmpty reports whether the backend holds no keys at all
+func (s *Store) isEmpty() (bool, error) {
+	iter := s.db.NewIterator(nil)
+	defer iter.Release()
+	if iter.Next() {
+		return false, nil
+	}
+	return true, iter.Error()
+}
+
+// migrateLegacyBlocks moves the blocks of the old single-node store, kept
+// under their raw hash with a height_<n> key pointing at them, to genesis
+// and block_<n>. Each block moves in its own batch together with the removal
+// of its old keys, so a rerun only sees the blocks not yet moved.
+func migrateLegacyBlocks(s *Store, dryRun bool) (int, error) {
+	type legacyBlock struct {
+		height int
+		hash   []byte
+	}
+	var blocks []legacyBlock
+	iter := s.db.NewIterator([]byte(legacyHeightPrefix))
+	for iter.Next() {
+		height, err := strconv.Atoi(string(iter.Key()[len(legacyHeightPrefix):]))
+		if err != nil || height < 0 {
+			continue
+		}
+		blocks = append(blocks, legacyBlock{height: height, hash: bytes.Clone(iter.Value())})
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return 0, fmt.Errorf("failed to scan legacy blocks: %w", err)
+	}
+	if dryRun {
+		return len(blocks), nil
+	}
+
+	for _, block := range blocks {
+		data, err := s.db.Get(block.hash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get legacy block %d: %w", block.height, err)
+		}
+		batch := new(Batch)
+		batch.Put([]byte(blockKey(block.height)), data)
+		batch.Delete(block.hash)
+		batch.Delete([]byte(legacyHeightPrefix + strconv.Itoa(block.height)))
+		if err := s.db.Write(batch); err != nil {
+			return 0, fmt.Errorf("failed to move legacy block %d: %w", block.height, err)
+		}
+	}
+	return len(blocks), nil
+}
+
+// migrateIndexes rebuilds every index from the blocks, for stores written
+// before the tip record or the transaction and address history indexes
+func migrateIndexes(s *Store, dryRun bool) (int, error) {
+	if dryRun {
+		return s.countBlocks()
+	}
+	if err := s.Rebuild(); err != nil {
+		return 0, err
+	}
+	if err := s.db.Delete([]byte(legacyIndexVersion)); err != nil {
+		return 0, fmt.Errorf("failed to drop index version: %w", err)
+	}
+	height, err := s.LatestHeight()
+	return height + 1, err
+}
+
+// countBlocks returns the number of blocks stored, gaps included
+func (s *Store) countBlocks() (int, error) {
+	count := 0
+	if ok, err := s.db.Has([]byte(genesisKey)); err != nil {
+		return 0, err
+	} else if ok {
+		count++
+	}
+	iter := s.db.NewIterator([]byte(blockKeyPrefix))
+	defer iter.Release()
+	for iter.Next() {
+		count++
+	}
+	return count, iter.Error()
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// legacyStore lays blocks out as the old single-node store did, under their
+// raw hash with a height_<n> key, except for the first moved blocks, which
+// are already at their height keys as after an interrupted migration. The
+// store is not migrated.
+func legacyStore(t *testing.T, blocks []*blockchain.Block, moved int) *Store {
+	t.Helper()
+	s := &Store{db: newMemory()}
+	for _, block := range blocks {
+		data, err := json.Marshal(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if block.Index < moved {
+			err = s.db.Put([]byte(blockKey(block.Index)), data)
+		} else {
+			err = s.db.Put(block.CurrentBlockHash, data)
+			if err == nil {
+				err = s.db.Put([]byte(legacyHeightPrefix+strconv.Itoa(block.Index)), block.CurrentBlockHash)
+			}
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.db.Put([]byte(legacyIndexVersion), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// allEntries returns every key of a store and its value
+func allEntries(t *testing.T, s *Store) map[string]string {
+	t.Helper()
+	entries := make(map[string]string)
+	iter := s.db.NewIterator(nil)
+	defer iter.Release()
+	for iter.Next() {
+		entries[string(iter.Key())] = string(iter.Value())
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+// recordMigrations returns a report func and the version:blocks it saw
+func recordMigrations() (func(Migration, int), *[]string) {
+	var reports []string
+	return func(m Migration, blocks int) {
+		reports = append(reports, fmt.Sprintf("%d:%d", m.Version, blocks))
+	}, &reports
+}
+
+func TestMigrate(t *testing.T) {
+	blocks := testChain(3)
+	// A migrated store holds exactly what a store written by this code does
+	want := NewMemory()
+	writeChain(t, want, blocks)
+	expected := allEntries(t, want)
+
+	tests := []struct {
+		name        string
+		store       func(t *testing.T) *Store
+		wantReports []string
+	}{
+		{"legacy store", func(t *testing.T) *Store {
+			return legacyStore(t, blocks, 0)
+		}, []string{"1:4", "2:4"}},
+		{"interrupted while moving blocks", func(t *testing.T) *Store {
+			return legacyStore(t, blocks, 2)
+		}, []string{"1:2", "2:4"}},
+		{"interrupted before the rebuild", func(t *testing.T) *Store {
+			s := legacyStore(t, blocks, len(blocks))
+			if err := s.setSchemaVersion(1); err != nil {
+				t.Fatal(err)
+			}
+			return s
+		}, []string{"2:4"}},
+		{"interrupted in the rebuild", func(t *testing.T) *Store {
+			// The rebuild dropped the tip and wrote part of the indexes
+			s := legacyStore(t, blocks, len(blocks))
+			if err := s.setSchemaVersion(1); err != nil {
+				t.Fatal(err)
+			}
+			batch := new(Batch)
+			if err := indexAddresses(batch, blocks[1].Transactions[0], txHash(t, blocks[1]), 1, 0); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.db.Write(batch); err != nil {
+				t.Fatal(err)
+			}
+			return s
+		}, []string{"2:4"}},
+		{"current store", func(t *testing.T) *Store {
+			s := NewMemory()
+			writeChain(t, s, blocks)
+			return s
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.store(t)
+			report, reports := recordMigrations()
+			if err := s.Migrate(false, report); err != nil {
+				t.Fatalf("Migrate() = %v", err)
+			}
+			if !reflect.DeepEqual(*reports, tt.wantReports) {
+				t.Errorf("migrations run = %v, want %v", *reports, tt.wantReports)
+			}
+			if version, err := s.SchemaVersion(); err != nil || version != LatestSchema() {
+				t.Errorf("SchemaVersion() = %d, %v, want %d", version, err, LatestSchema())
+			}
+
+			got := allEntries(t, s)
+			for key, value := range expected {
+				if got[key] != value {
+					t.Errorf("entry %s = %q, want %q", key, got[key], value)
+				}
+			}
+			for key := range got {
+				if _, ok := expected[key]; !ok {
+					t.Errorf("unexpected entry %q after migrating", key)
+				}
+			}
+
+			// Migrating again finds nothing to do
+			report, reports = recordMigrations()
+			if err := s.Migrate(false, report); err != nil || len(*reports) != 0 {
+				t.Errorf("second Migrate() = %v and ran %v", err, *reports)
+			}
+		})
+	}
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	blocks := testChain(3)
+	tests := []struct {
+		name        string
+		moved       int
+		wantReports []string
+	}{
+		// Counts are of the store as it is, before earlier migrations run
+		{"legacy store", 0, []string{"1:4", "2:0"}},
+		{"interrupted while moving blocks", 2, []string{"1:2", "2:2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := legacyStore(t, blocks, tt.moved)
+			before := allEntries(t, s)
+
+			report, reports := recordMigrations()
+			if err := s.Migrate(true, report); err != nil {
+				t.Fatalf("Migrate() = %v", err)
+			}
+			if !reflect.DeepEqual(*reports, tt.wantReports) {
+				t.Errorf("migrations reported = %v, want %v", *reports, tt.wantReports)
+			}
+			if after := allEntries(t, s); !reflect.DeepEqual(after, before) {
+				t.Error("dry run changed the store")
+			}
+			if pending, err := s.PendingMigrations(); err != nil || len(pending) != len(migrations) {
+				t.Errorf("PendingMigrations() = %d, %v after a dry run, want %d", len(pending), err, len(migrations))
+			}
+		})
+	}
+}
+
+func TestMigrateRefusesNewerSchema(t *testing.T) {
+	backend := newMemory()
+	s := &Store{db: backend}
+	if err := s.setSchemaVersion(LatestSchema() + 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Migrate(false, nil); err == nil {
+		t.Error("Migrate() accepted a newer schema")
+	}
+	if _, err := NewStore(backend); err == nil {
+		t.Error("NewStore() opened a store with a newer schema")
+	}
+}
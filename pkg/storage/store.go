@@ -0,0 +1,347 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// Chain store layout. State keys (balances, nonces, ...) are written as they
+// are by the blockchain package; the store adds the blocks and their indexes:
+//
+//	genesis            block 0 (JSON)
+//	block_<height>     blocks 1.. (JSON)
+//	blockhash_<hex>    height of the block with that hash
+//	tx_<hex>           height and position of the transaction with that hash (JSON)
+//	addrtx_<hex>_...   transactions sent or received by an address (see history.go)
+//	tip                height and hash of the last block written (JSON)
+//	schema_version     last migration applied (see migrate.go)
+const (
+	genesisKey      = "genesis"
+	blockKeyPrefix  = "block_"
+	blockHashPrefix = "blockhash_"
+	txKeyPrefix     = "tx_"
+	tipKey          = "tip"
+)
+
+// ErrNotFound is returned when a block or index entry does not exist
+var ErrNotFound = errors.New("not found")
+
+func blockKey(height int) string {
+	if height == 0 {
+		return genesisKey
+	}
+	return blockKeyPrefix + strconv.Itoa(height)
+}
+
+func blockHashKey(hash []byte) string {
+	return blockHashPrefix + hex.EncodeToString(hash)
+}
+
+func txKey(hash []byte) string {
+	return txKeyPrefix + hex.EncodeToString(hash)
+}
+
+// Tip is the persisted record of the last block written
+type Tip struct {
+	Height int    `json:"height"`
+	Hash   []byte `json:"hash"`
+}
+
+// Store is the chain store: blocks, their indexes, the tip and the state in
+// one backend. WriteBlock commits all of them for a block in a single batch,
+// so a crash never leaves a block without its index, state changes or tip
+// update.
+type Store struct {
+	db Backend
+}
+
+// NewStore opens the chain store kept in a backend. It applies the pending
+// schema migrations, then repairs the tip record and indexes if they do not
+// match the stored blocks (after a crash or damage).
+func NewStore(db Backend) (*Store, error) {
+	store := &Store{db: db}
+	if err := store.Migrate(false, logMigration); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := store.checkTip(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *Store) Get(key string) ([]byte, error) {
+	return s.db.Get([]byte(key))
+}
+
+func (s *Store) Put(key string, value []byte) error {
+	return s.db.Put([]byte(key), value)
+}
+
+// Backend returns the key-value database under the store, for iteration and
+// snapshots
+func (s *Store) Backend() Backend {
+	return s.db
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// WriteBlock atomically stores a block, its height and hash indexes, the
+// state changes it made and the new tip
+func (s *Store) WriteBlock(block *blockchain.Block, changes []blockchain.StateChange) error {
+	blockData, err := json.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("failed to marshal block: %w", err)
+	}
+
+	batch := new(Batch)
+	batch.Put([]byte(blockKey(block.Index)), blockData)
+	if err := indexBlock(batch, block); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		batch.Put([]byte(change.Key), change.Value)
+	}
+	tip, err := json.Marshal(&Tip{Height: block.Index, Hash: block.CurrentBlockHash})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tip: %w", err)
+	}
+	batch.Put([]byte(tipKey), tip)
+
+	if err := s.db.Write(batch); err != nil {
+		return fmt.Errorf("failed to write block %d: %w", block.Index, err)
+	}
+	return nil
+}
+
+// indexBlock adds the hash, transaction and address history index entries
+// of a block to a batch
+func indexBlock(batch *Batch, block *blockchain.Block) error {
+	batch.Put([]byte(blockHashKey(block.CurrentBlockHash)), []byte(strconv.Itoa(block.Index)))
+	for position, tx := range block.Transactions {
+		hash, err := tx.Hash()
+		if err != nil {
+			return fmt.Errorf("failed to hash transaction %d of block %d: %w", position, block.Index, err)
+		}
+		location, err := json.Marshal(&blockchain.TxLocation{Height: block.Index, Position: position})
+		if err != nil {
+			return fmt.Errorf("failed to marshal transaction location: %w", err)
+		}
+		batch.Put([]byte(txKey(hash)), location)
+		if err := indexAddresses(batch, tx, hash, block.Index, position); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TxLocation returns where the transaction with the given hash was committed
+func (s *Store) TxLocation(hash []byte) (*blockchain.TxLocation, error) {
+	data, err := s.db.Get([]byte(txKey(hash)))
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("transaction %x %w", hash, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %x: %w", hash, err)
+	}
+	var location blockchain.TxLocation
+	if err := json.Unmarshal(data, &location); err != nil {
+		return nil, fmt.Errorf("invalid location of transaction %x: %w", hash, err)
+	}
+	return &location, nil
+}
+
+// BlockByHeight returns the block at a height
+func (s *Store) BlockByHeight(height int) (*blockchain.Block, error) {
+	data, err := s.db.Get([]byte(blockKey(height)))
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("block at height %d %w", height, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+
+	var block blockchain.Block
+	if err := json.Unmarshal(data, &block); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal block %d: %w", height, err)
+	}
+	return &block, nil
+}
+
+// BlockHeight returns the height of the block with the given hash
+func (s *Store) BlockHeight(hash []byte) (int, error) {
+	data, err := s.db.Get([]byte(blockHashKey(hash)))
+	if errors.Is(err, ErrNotFound) {
+		return 0, fmt.Errorf("block %x %w", hash, ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block %x: %w", hash, err)
+	}
+	height, err := strconv.Atoi(string(data))
+	if err != nil {
+		return 0, fmt.Errorf("invalid height index of block %x: %w", hash, err)
+	}
+	return height, nil
+}
+
+// BlockByHash returns the block with the given hash
+func (s *Store) BlockByHash(hash []byte) (*blockchain.Block, error) {
+	height, err := s.BlockHeight(hash)
+	if err != nil {
+		return nil, err
+	}
+	return s.BlockByHeight(height)
+}
+
+// Tip returns the record of the last block written, or nil for an empty
+// store. It is a single read however long the chain is.
+func (s *Store) Tip() (*Tip, error) {
+	data, err := s.db.Get([]byte(tipKey))
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tip: %w", err)
+	}
+	var tip Tip
+	if err := json.Unmarshal(data, &tip); err != nil {
+		return nil, fmt.Errorf("invalid tip record: %w", err)
+	}
+	return &tip, nil
+}
+
+// LatestHeight returns the height of the tip, or -1 for an empty store
+func (s *Store) LatestHeight() (int, error) {
+	tip, err := s.Tip()
+	if err != nil || tip == nil {
+		return -1, err
+	}
+	return tip.Height, nil
+}
+
+// checkTip rebuilds the tip and indexes when the tip record is missing while
+// blocks exist, or names a block that is not the last one stored
+func (s *Store) checkTip() error {
+	tip, err := s.Tip()
+	if err != nil {
+		return s.Rebuild()
+	}
+	if tip == nil {
+		if _, err := s.db.Get([]byte(genesisKey)); errors.Is(err, ErrNotFound) {
+			return nil // Empty store
+		}
+		return s.Rebuild()
+	}
+
+	block, err := s.BlockByHeight(tip.Height)
+	if err != nil || !bytes.Equal(block.CurrentBlockHash, tip.Hash) {
+		return s.Rebuild()
+	}
+	if ok, err := s.db.Has([]byte(blockKey(tip.Height + 1))); err != nil || ok {
+		return s.Rebuild()
+	}
+	return nil
+}
+
+// rebuildBatchSize bounds the operations Rebuild writes per batch, so that
+// rebuilding a long chain never holds its whole index in memory
+var rebuildBatchSize = 10000
+
+// Rebuild recomputes the tip record and the hash, transaction and address
+// history indexes from the stored blocks. It is the only operation that walks
+// every key of the store: the tip becomes the highest height reached from
+// genesis without a gap, and index entries of blocks past it are dropped.
+//
+// The tip record is removed first and written back last, so a rebuild that
+// is interrupted runs again the next time the store is opened.
+func (s *Store) Rebuild() error {
+	// Scan a snapshot so that blocks written meanwhile cannot tear the scan
+	snap, err := s.db.Snapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+
+	last := -1
+	for {
+		ok, err := snap.Has([]byte(blockKey(last + 1)))
+		if err != nil {
+			return fmt.Errorf("failed to scan blocks: %w", err)
+		}
+		if !ok {
+			break
+		}
+		last++
+	}
+
+	if err := s.db.Delete([]byte(tipKey)); err != nil {
+		return fmt.Errorf("failed to drop tip: %w", err)
+	}
+
+	// Clear the old index entries, including those of blocks past the tip
+	batch := new(Batch)
+	for _, prefix := range []string{blockHashPrefix, txKeyPrefix, addressTxPrefix} {
+		iter := snap.NewIterator([]byte(prefix))
+		for iter.Next() {
+			batch.Delete(bytes.Clone(iter.Key()))
+			if batch, err = s.flushRebuild(batch, false); err != nil {
+				iter.Release()
+				return err
+			}
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return fmt.Errorf("failed to scan %s index: %w", prefix, err)
+		}
+	}
+
+	var tip *Tip
+	for height := 0; height <= last; height++ {
+		data, err := snap.Get([]byte(blockKey(height)))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", height, err)
+		}
+		var block blockchain.Block
+		if err := json.Unmarshal(data, &block); err != nil {
+			return fmt.Errorf("failed to unmarshal block %d: %w", height, err)
+		}
+		if err := indexBlock(batch, &block); err != nil {
+			return err
+		}
+		if batch, err = s.flushRebuild(batch, false); err != nil {
+			return err
+		}
+		tip = &Tip{Height: height, Hash: block.CurrentBlockHash}
+	}
+
+	if tip != nil {
+		data, err := json.Marshal(tip)
+		if err != nil {
+			return fmt.Errorf("failed to marshal tip: %w", err)
+		}
+		batch.Put([]byte(tipKey), data)
+	}
+	_, err = s.flushRebuild(batch, true)
+	return err
+}
+
+// flushRebuild writes a batch of rebuilt index entries once it is full, or
+// at the end, and returns the batch to continue with
+func (s *Store) flushRebuild(batch *Batch, final bool) (*Batch, error) {
+	if batch.Len() == 0 || (!final && batch.Len() < rebuildBatchSize) {
+		return batch, nil
+	}
+	if err := s.db.Write(batch); err != nil {
+		return nil, fmt.Errorf("failed to write rebuilt indexes: %w", err)
+	}
+	return new(Batch), nil
+}
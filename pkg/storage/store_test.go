@@ -0,0 +1,337 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
+)
+
+// testAddress returns a distinct address for each n
+func testAddress(n int) []byte {
+	return bytes.Repeat([]byte{byte(n)}, blockchain.AddressLength)
+}
+
+// testChain builds blocks 0..last. Block h pays testAddress(100+h), an
+// address no other block touches, from one of three senders.
+func testChain(last int) []*blockchain.Block {
+	var blocks []*blockchain.Block
+	var prevHash []byte
+	for height := 0; height <= last; height++ {
+		sender := testAddress(1 + height%3)
+		if height == 0 {
+			sender = blockchain.GenesisSender
+		}
+		tx := &blockchain.Transaction{
+			Sender:    sender,
+			Receiver:  testAddress(100 + height),
+			Amount:    float64(height + 1),
+			Timestamp: int64(1700000000 + height),
+		}
+		block := blockchain.NewBlock(height, []*blockchain.Transaction{tx}, prevHash)
+		blocks = append(blocks, block)
+		prevHash = block.CurrentBlockHash
+	}
+	return blocks
+}
+
+func writeChain(t *testing.T, s *Store, blocks []*blockchain.Block) {
+	t.Helper()
+	for _, block := range blocks {
+		if err := s.WriteBlock(block, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// indexEntries returns the tip record and every index entry of a store
+func indexEntries(t *testing.T, s *Store) map[string]string {
+	t.Helper()
+	entries := make(map[string]string)
+	for _, prefix := range []string{tipKey, blockHashPrefix, txKeyPrefix, addressTxPrefix} {
+		iter := s.db.NewIterator([]byte(prefix))
+		for iter.Next() {
+			entries[string(iter.Key())] = string(iter.Value())
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return entries
+}
+
+func txHash(t *testing.T, block *blockchain.Block) []byte {
+	t.Helper()
+	hash, err := block.Transactions[0].Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+// failingBackend is a memory backend whose batch writes can be made to fail
+type failingBackend struct {
+	*memory
+	fail bool
+}
+
+func (b *failingBackend) Write(batch *Batch) error {
+	if b.fail {
+		return errors.New("disk full")
+	}
+	return b.memory.Write(batch)
+}
+
+func TestWriteBlockIsAtomic(t *testing.T) {
+	blocks := testChain(2)
+	changes := []blockchain.StateChange{{Key: "balance_test", Value: []byte("42")}}
+	// One key of each kind that block 2 adds
+	keys := []string{
+		blockKey(2),
+		blockHashKey(blocks[2].CurrentBlockHash),
+		txKey(txHash(t, blocks[2])),
+		addressHistoryPrefix(testAddress(102)) + historyCursor(2, 0),
+		"balance_test",
+	}
+
+	tests := []struct {
+		name    string
+		fail    bool
+		stored  bool
+		wantTip int
+	}{
+		{"committed", false, true, 2},
+		{"failed write", true, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &failingBackend{memory: newMemory()}
+			s, err := NewStore(backend)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeChain(t, s, blocks[:2])
+			before, err := s.db.Snapshot()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer before.Release()
+
+			backend.fail = tt.fail
+			if err := s.WriteBlock(blocks[2], changes); (err == nil) != tt.stored {
+				t.Fatalf("WriteBlock() = %v, want ok=%t", err, tt.stored)
+			}
+			for _, key := range keys {
+				if ok, _ := s.db.Has([]byte(key)); ok != tt.stored {
+					t.Errorf("key %s stored = %t, want %t", key, ok, tt.stored)
+				}
+				if ok, _ := before.Has([]byte(key)); ok {
+					t.Errorf("snapshot taken before the write sees %s", key)
+				}
+			}
+			if height, err := s.LatestHeight(); err != nil || height != tt.wantTip {
+				t.Errorf("LatestHeight() = %d, %v, want %d", height, err, tt.wantTip)
+			}
+		})
+	}
+}
+
+func TestRebuildDropsIndexesPastTip(t *testing.T) {
+	defer func(size int) { rebuildBatchSize = size }(rebuildBatchSize)
+	rebuildBatchSize = 3 // Several batches for every step
+
+	blocks := testChain(6)
+	s := NewMemory()
+	writeChain(t, s, blocks)
+
+	// Lose blocks 4 and 5; block 6 is past the gap and is not reachable
+	for _, height := range []int{4, 5} {
+		if err := s.db.Delete([]byte(blockKey(height))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Rebuild(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The indexes are exactly those of a store that only held blocks 0..3
+	want := NewMemory()
+	writeChain(t, want, blocks[:4])
+	got, expected := indexEntries(t, s), indexEntries(t, want)
+	for key, value := range expected {
+		if got[key] != value {
+			t.Errorf("entry %s = %q, want %q", key, got[key], value)
+		}
+	}
+	for key := range got {
+		if _, ok := expected[key]; !ok {
+			t.Errorf("stale entry %s survived the rebuild", key)
+		}
+	}
+
+	if height, err := s.LatestHeight(); err != nil || height != 3 {
+		t.Errorf("LatestHeight() = %d, %v, want 3", height, err)
+	}
+	for _, block := range blocks[4:] {
+		if _, err := s.BlockHeight(block.CurrentBlockHash); !errors.Is(err, ErrNotFound) {
+			t.Errorf("BlockHeight(block %d) = %v, want ErrNotFound", block.Index, err)
+		}
+		if _, err := s.TxLocation(txHash(t, block)); !errors.Is(err, ErrNotFound) {
+			t.Errorf("TxLocation(transaction of block %d) = %v, want ErrNotFound", block.Index, err)
+		}
+	}
+}
+
+func TestOpenRepairsTip(t *testing.T) {
+	blocks := testChain(3)
+	putTip := func(height int, hash []byte) func(*Store) error {
+		return func(s *Store) error {
+			data, _ := json.Marshal(&Tip{Height: height, Hash: hash})
+			return s.db.Put([]byte(tipKey), data)
+		}
+	}
+	deleteKeys := func(keys ...string) func(*Store) error {
+		return func(s *Store) error {
+			for _, key := range keys {
+				if err := s.db.Delete([]byte(key)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		blocks  int // Blocks written before the damage
+		damage  func(*Store) error
+		wantTip int // -1 for an empty store
+	}{
+		{"intact", 4, deleteKeys(), 3},
+		{"empty store", 0, deleteKeys(), -1},
+		{"missing tip", 4, deleteKeys(tipKey), 3},
+		{"corrupt tip", 4, func(s *Store) error { return s.db.Put([]byte(tipKey), []byte("{")) }, 3},
+		{"tip names another block", 4, putTip(3, blocks[2].CurrentBlockHash), 3},
+		{"tip behind last block", 4, putTip(1, blocks[1].CurrentBlockHash), 3},
+		{"tip past last block", 4, putTip(7, blocks[3].CurrentBlockHash), 3},
+		{"missing tip above a gap", 4, deleteKeys(tipKey, blockKey(2)), 1},
+		{"interrupted rebuild", 4, deleteKeys(tipKey, blockHashKey(blocks[1].CurrentBlockHash), txKey(txHash(t, blocks[3]))), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newMemory()
+			s, err := NewStore(backend)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeChain(t, s, blocks[:tt.blocks])
+			if err := tt.damage(s); err != nil {
+				t.Fatal(err)
+			}
+
+			reopened, err := NewStore(backend)
+			if err != nil {
+				t.Fatalf("NewStore() = %v", err)
+			}
+			if height, err := reopened.LatestHeight(); err != nil || height != tt.wantTip {
+				t.Errorf("LatestHeight() = %d, %v, want %d", height, err, tt.wantTip)
+			}
+
+			want := NewMemory()
+			writeChain(t, want, blocks[:tt.wantTip+1])
+			got, expected := indexEntries(t, reopened), indexEntries(t, want)
+			if len(got) != len(expected) {
+				t.Errorf("%d index entries, want %d", len(got), len(expected))
+			}
+			for key, value := range expected {
+				if got[key] != value {
+					t.Errorf("entry %s = %q, want %q", key, got[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestGetTransaction(t *testing.T) {
+	signer, err := wallet.GenerateSigner(blockchain.SchemeP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewMemory()
+	bc, err := blockchain.NewBlockchainWithGenesis(store, &blockchain.Genesis{
+		ChainID:     "test-chain",
+		Allocations: []blockchain.GenesisAllocation{{Address: signer.Address(), Amount: 100}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Block 1 holds nonces 1 and 2, block 2 nonce 3
+	var txs []*blockchain.Transaction
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		tx := &blockchain.Transaction{
+			Sender:    signer.Address(),
+			Receiver:  testAddress(int(nonce)),
+			Amount:    1,
+			Timestamp: 1700000000,
+			Nonce:     nonce,
+			ChainID:   "test-chain",
+		}
+		if err := wallet.SignTransactionWith(tx, signer); err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, tx)
+	}
+	for _, batch := range [][]*blockchain.Transaction{txs[:2], txs[2:]} {
+		latest := bc.GetLatestBlock()
+		if err := bc.AddBlock(blockchain.NewBlock(latest.Index+1, batch, latest.CurrentBlockHash)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	genesis, err := bc.GetBlockByHeight(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		tx       *blockchain.Transaction
+		height   int
+		position int
+	}{
+		{"genesis allocation", genesis.Transactions[0], 0, 0},
+		{"first in block", txs[0], 1, 0},
+		{"second in block", txs[1], 1, 1},
+		{"next block", txs[2], 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.tx.Hash()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tx, block, position, err := bc.GetTransaction(hash)
+			if err != nil {
+				t.Fatalf("GetTransaction() = %v", err)
+			}
+			if block.Index != tt.height || position != tt.position {
+				t.Errorf("found at %d/%d, want %d/%d", block.Index, position, tt.height, tt.position)
+			}
+			if got, _ := tx.Hash(); !bytes.Equal(got, hash) {
+				t.Errorf("GetTransaction() returned transaction %x, want %x", got, hash)
+			}
+		})
+	}
+
+	unknown := bytes.Repeat([]byte{0xee}, 32)
+	if _, _, _, err := bc.GetTransaction(unknown); err == nil {
+		t.Error("GetTransaction() found an unknown hash")
+	}
+	if _, err := store.TxLocation(unknown); !errors.Is(err, ErrNotFound) {
+		t.Errorf("TxLocation() = %v, want ErrNotFound", err)
+	}
+}
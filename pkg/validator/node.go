@@ -9,7 +9,9 @@ import (
 
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/p2p"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/signer"
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/storage"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/wallet"
 )
 
 type ValidatorNode struct {
@@ -17,9 +19,9 @@ type ValidatorNode struct {
 	IsLeader     bool
 	Peers        []string
 	Blockchain   *blockchain.Blockchain
-	Storage      *storage.LevelDB
+	Storage      *storage.Store
 	Server       *p2p.BlockchainServer
-	blockStorage *storage.BlockStorage
+	blockStorage *storage.Store // Chain store of the legacy single-node CLI
 }
 
 func NewValidatorNode() (*ValidatorNode, error) {
@@ -37,19 +39,65 @@ func NewValidatorNode() (*ValidatorNode, error) {
 		peers = strings.Split(peersStr, ",")
 	}
 
+	if err := wallet.UseNetworkEnv(); err != nil {
+		return nil, err
+	}
+
 	dbPath := fmt.Sprintf("data/%s", nodeID)
-	storage, err := storage.NewLevelDB(dbPath)
+	storage, err := storage.Open(os.Getenv("STORAGE_BACKEND"), dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create storage: %w", err)
 	}
 
-	blockchain, err := blockchain.NewBlockchain(storage)
+	genesis := blockchain.DefaultGenesis()
+	if genesisFile := os.Getenv("GENESIS_FILE"); genesisFile != "" {
+		if genesis, err = blockchain.LoadGenesis(genesisFile, wallet.DecodeAddress); err != nil {
+			return nil, fmt.Errorf("failed to load genesis: %w", err)
+		}
+	}
+
+	blockchain, err := blockchain.NewBlockchainWithGenesis(storage, genesis)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create blockchain: %w", err)
 	}
 
 	server := p2p.NewBlockchainServer(nodeID, blockchain, storage, peers, isLeader)
 
+	if minFeeStr := os.Getenv("MIN_TX_FEE"); minFeeStr != "" {
+		minFee, err := strconv.ParseFloat(minFeeStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MIN_TX_FEE: %w", err)
+		}
+		server.SetMinFee(minFee)
+	}
+	if rewardAddr := os.Getenv("REWARD_ADDRESS"); rewardAddr != "" {
+		address, err := wallet.ParseAddress(rewardAddr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REWARD_ADDRESS: %w", err)
+		}
+		server.SetRewardAddress(address)
+	}
+
+	// Proposals and votes are signed by a key held in the signer daemon
+	if socket := os.Getenv("SIGNER_SOCKET"); socket != "" {
+		key, err := signer.DialKey(socket, os.Getenv("SIGNER_KEY"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SIGNER_SOCKET: %w", err)
+		}
+		server.SetSigner(key)
+	}
+	if validators := os.Getenv("VALIDATOR_ADDRESSES"); validators != "" {
+		var addresses [][]byte
+		for _, v := range strings.Split(validators, ",") {
+			address, err := wallet.ParseAddress(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid VALIDATOR_ADDRESSES: %w", err)
+			}
+			addresses = append(addresses, address)
+		}
+		server.SetValidators(addresses)
+	}
+
 	return &ValidatorNode{
 		NodeID:     nodeID,
 		IsLeader:   isLeader,
@@ -61,7 +109,7 @@ func NewValidatorNode() (*ValidatorNode, error) {
 }
 
 func NewValidatorNodeLegacy(dbPath string) (*ValidatorNode, error) {
-	blockStorage, err := storage.NewBlockStorage(dbPath)
+	blockStorage, err := storage.NewLevelDB(dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create storage: %w", err)
 	}
@@ -79,9 +127,9 @@ func (vn *ValidatorNode) CloseLegacy() error {
 
 func (vn *ValidatorNode) CreateBlock(transactions []*blockchain.Transaction) (*blockchain.Block, error) {
 	var prevHash []byte
-	latestIndex, err := vn.blockStorage.GetLatestIndex()
+	latestIndex, err := vn.blockStorage.LatestHeight()
 	if err == nil && latestIndex >= 0 {
-		prevBlock, err := vn.blockStorage.GetBlockByIndex(latestIndex)
+		prevBlock, err := vn.blockStorage.BlockByHeight(latestIndex)
 		if err == nil {
 			prevHash = prevBlock.CurrentBlockHash
 		}
@@ -93,20 +141,16 @@ func (vn *ValidatorNode) CreateBlock(transactions []*blockchain.Transaction) (*b
 		return nil, fmt.Errorf("block invalid - Merkle Tree verification failed")
 	}
 
-	if err := vn.blockStorage.SaveBlock(newBlock); err != nil {
+	if err := vn.blockStorage.WriteBlock(newBlock, nil); err != nil {
 		return nil, fmt.Errorf("failed to save block: %w", err)
 	}
 
-	if err := vn.blockStorage.StoreBlockByIndex(newBlock); err != nil {
-		return nil, fmt.Errorf("failed to store block index: %w", err)
-	}
-
 	return newBlock, nil
 }
 
 func (vn *ValidatorNode) GetBlock(hash []byte) (*blockchain.Block, error) {
 	if vn.blockStorage != nil {
-		return vn.blockStorage.GetBlock(hash)
+		return vn.blockStorage.BlockByHash(hash)
 	}
 	return nil, fmt.Errorf("not implemented for new storage")
 }
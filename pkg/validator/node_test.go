@@ -0,0 +1,45 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// BenchmarkCreateBlock measures adding one block on top of chains of
+// increasing length. Finding the tip is a single read of the tip record, so
+// the cost per block should not grow with the chain.
+func BenchmarkCreateBlock(b *testing.B) {
+	for _, length := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("chain=%d", length), func(b *testing.B) {
+			node, err := NewValidatorNodeLegacy(b.TempDir())
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer node.CloseLegacy()
+
+			for i := 0; i < length; i++ {
+				if _, err := node.CreateBlock(benchTransactions(i)); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := node.CreateBlock(benchTransactions(length + i)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchTransactions(i int) []*blockchain.Transaction {
+	return []*blockchain.Transaction{{
+		Sender:    []byte("sender"),
+		Receiver:  []byte("receiver"),
+		Amount:    1,
+		Timestamp: int64(i),
+	}}
+}
@@ -0,0 +1,444 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+const (
+	// DefaultWalletDir is the wallet directory used when none is configured
+	DefaultWalletDir = "wallet"
+
+	// WalletDirEnv names the environment variable that overrides it
+	WalletDirEnv = "WALLET_DIR"
+
+	walletConfigFile = "wallet.json"
+	walletKeysDir    = "keys"
+)
+
+// ErrNoAccount is returned when a wallet has no account of the requested name
+var ErrNoAccount = errors.New("no such account")
+
+var accountNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,31}$`)
+
+// Wallet is a directory of named accounts:
+//
+//	wallet/
+//	  wallet.json        {"default": "alice", "watch": [{"name": "deposit-1", "address": "<40-hex>"}]}
+//	  keys/alice.json    encrypted key file of account "alice"
+//	  keys/bob.json
+//	  scan.json          history of the accounts, see Scanner
+//
+// Each account is an ordinary encrypted key file, so account files can be
+// used anywhere a key file is expected. Watch-only accounts are addresses
+// whose keys the wallet does not hold; they can be scanned but not used to
+// sign.
+type Wallet struct {
+	dir    string
+	config walletConfig
+}
+
+type walletConfig struct {
+	Default string          `json:"default,omitempty"`
+	Watch   []watchedConfig `json:"watch,omitempty"`
+}
+
+type watchedConfig struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// Account is a named key of a wallet. Only the public part is read; the key
+// is unlocked with Wallet.Unlock.
+type Account struct {
+	Name      string
+	Address   Address
+	Scheme    blockchain.SignatureScheme // Empty for watch-only accounts
+	Default   bool
+	WatchOnly bool
+}
+
+// WalletDir returns the wallet directory from WALLET_DIR, or DefaultWalletDir
+func WalletDir() string {
+	if dir := os.Getenv(WalletDirEnv); dir != "" {
+		return dir
+	}
+	return DefaultWalletDir
+}
+
+// OpenWallet opens the wallet in dir. The directory is created when the
+// first account is added.
+func OpenWallet(dir string) (*Wallet, error) {
+	w := &Wallet{dir: dir}
+
+	data, err := os.ReadFile(filepath.Join(dir, walletConfigFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read wallet %s: %w", dir, err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &w.config); err != nil {
+			return nil, fmt.Errorf("failed to decode wallet %s: %w", dir, err)
+		}
+	}
+	return w, nil
+}
+
+// Dir returns the wallet directory
+func (w *Wallet) Dir() string {
+	return w.dir
+}
+
+// KeyPath returns the key file of the named account
+func (w *Wallet) KeyPath(name string) string {
+	return filepath.Join(w.dir, walletKeysDir, name+".json")
+}
+
+// DefaultAccount returns the name of the default account, if any
+func (w *Wallet) DefaultAccount() string {
+	return w.config.Default
+}
+
+// ScanPath returns the file holding the scanned history of the accounts
+func (w *Wallet) ScanPath() string {
+	return filepath.Join(w.dir, "scan.json")
+}
+
+// Accounts lists the accounts of the wallet, watch-only ones included,
+// sorted by name
+func (w *Wallet) Accounts() ([]*Account, error) {
+	paths, err := filepath.Glob(filepath.Join(w.dir, walletKeysDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(paths)+len(w.config.Watch))
+	for _, path := range paths {
+		names = append(names, strings.TrimSuffix(filepath.Base(path), ".json"))
+	}
+	for _, watched := range w.config.Watch {
+		names = append(names, watched.Name)
+	}
+	sort.Strings(names)
+
+	accounts := make([]*Account, 0, len(names))
+	for _, name := range names {
+		account, err := w.Account(name)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// Account returns the named account, or the default account if name is empty
+func (w *Wallet) Account(name string) (*Account, error) {
+	if name == "" {
+		if w.config.Default == "" {
+			return nil, fmt.Errorf("wallet %s has no default account", w.dir)
+		}
+		name = w.config.Default
+	}
+	if watched := w.watched(name); watched != nil {
+		address, err := hex.DecodeString(watched.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address of watched account %s: %w", name, err)
+		}
+		return &Account{Name: name, Address: address, WatchOnly: true}, nil
+	}
+	if !w.Has(name) {
+		return nil, fmt.Errorf("%w %q in wallet %s", ErrNoAccount, name, w.dir)
+	}
+
+	keyFile, err := ReadKeyFile(w.KeyPath(name))
+	if err != nil {
+		return nil, err
+	}
+	address, err := hex.DecodeString(keyFile.AccountAddress())
+	if err != nil {
+		return nil, fmt.Errorf("invalid address in account %s: %w", name, err)
+	}
+	return &Account{
+		Name:    name,
+		Address: address,
+		Scheme:  keyFile.Scheme,
+		Default: name == w.config.Default,
+	}, nil
+}
+
+// Has reports whether the wallet has an account of the given name
+func (w *Wallet) Has(name string) bool {
+	if !accountNamePattern.MatchString(name) {
+		return false
+	}
+	if w.watched(name) != nil {
+		return true
+	}
+	_, err := os.Stat(w.KeyPath(name))
+	return err == nil
+}
+
+func (w *Wallet) watched(name string) *watchedConfig {
+	for i := range w.config.Watch {
+		if w.config.Watch[i].Name == name {
+			return &w.config.Watch[i]
+		}
+	}
+	return nil
+}
+
+// Unlock decrypts the key of the named (or default) account
+func (w *Wallet) Unlock(name, passphrase string) (Signer, error) {
+	account, err := w.signingAccount(name)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSigner(w.KeyPath(account.Name), passphrase)
+}
+
+// signingAccount returns the named (or default) account, which must not be
+// watch-only
+func (w *Wallet) signingAccount(name string) (*Account, error) {
+	account, err := w.Account(name)
+	if err != nil {
+		return nil, err
+	}
+	if account.WatchOnly {
+		return nil, fmt.Errorf("account %s is watch-only; the wallet has no key for it", account.Name)
+	}
+	return account, nil
+}
+
+// Watch adds a watch-only account for an address whose key the wallet does
+// not hold
+func (w *Wallet) Watch(name string, address []byte) (*Account, error) {
+	if err := w.checkNewName(name); err != nil {
+		return nil, err
+	}
+	if len(address) != blockchain.AddressLength {
+		return nil, fmt.Errorf("invalid address length %d", len(address))
+	}
+	if err := w.checkNewAddress(address); err != nil {
+		return nil, err
+	}
+	if err := w.createDir(); err != nil {
+		return nil, err
+	}
+
+	w.config.Watch = append(w.config.Watch, watchedConfig{Name: name, Address: hex.EncodeToString(address)})
+	if err := w.save(); err != nil {
+		return nil, err
+	}
+	return w.Account(name)
+}
+
+// Unwatch removes a watch-only account
+func (w *Wallet) Unwatch(name string) error {
+	for i, watched := range w.config.Watch {
+		if watched.Name == name {
+			w.config.Watch = append(w.config.Watch[:i], w.config.Watch[i+1:]...)
+			return w.save()
+		}
+	}
+	return fmt.Errorf("%w %q among the watch-only accounts of %s", ErrNoAccount, name, w.dir)
+}
+
+// Create stores a key as a new account encrypted with passphrase. The first
+// account of a wallet becomes its default.
+func (w *Wallet) Create(name string, signer Signer, passphrase string) (*Account, error) {
+	if err := w.checkNewName(name); err != nil {
+		return nil, err
+	}
+	if err := w.checkNewAddress(signer.Address()); err != nil {
+		return nil, err
+	}
+	if err := w.createDir(); err != nil {
+		return nil, err
+	}
+	if err := SaveSigner(w.KeyPath(name), signer, passphrase); err != nil {
+		return nil, err
+	}
+	return w.added(name)
+}
+
+// Import copies an existing encrypted key file into the wallet as a new
+// account. The key stays encrypted with its original passphrase.
+func (w *Wallet) Import(name, keyFilePath string) (*Account, error) {
+	if err := w.checkNewName(name); err != nil {
+		return nil, err
+	}
+	keyFile, err := ReadKeyFile(keyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if keyFile.Version != KeystoreVersion {
+		return nil, fmt.Errorf("unsupported key file version %d", keyFile.Version)
+	}
+	address, err := hex.DecodeString(keyFile.AccountAddress())
+	if err != nil || len(address) != 20 {
+		return nil, fmt.Errorf("invalid address in key file %s", keyFilePath)
+	}
+	if err := w.checkNewAddress(address); err != nil {
+		return nil, err
+	}
+	if err := w.createDir(); err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(keyFile, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key file: %w", err)
+	}
+	if err := writeFileAtomic(w.KeyPath(name), data); err != nil {
+		return nil, err
+	}
+	return w.added(name)
+}
+
+// ReplaceKey stores the key an account was rotated to, encrypted with
+// passphrase. The previous key file is kept next to it with a ".rotated-<unix
+// time>" suffix so that it can still be recovered if the rotation never
+// commits.
+func (w *Wallet) ReplaceKey(name string, signer Signer, passphrase string) (string, error) {
+	account, err := w.signingAccount(name)
+	if err != nil {
+		return "", err
+	}
+	signer = RotatedSigner(signer, account.Address)
+
+	path := w.KeyPath(account.Name)
+	backup := fmt.Sprintf("%s.rotated-%d", path, time.Now().Unix())
+	if err := os.Rename(path, backup); err != nil {
+		return "", fmt.Errorf("failed to back up the key of %s: %w", account.Name, err)
+	}
+	if err := SaveSigner(path, signer, passphrase); err != nil {
+		os.Rename(backup, path)
+		return "", err
+	}
+	return backup, nil
+}
+
+// Export copies the encrypted key file of an account to path
+func (w *Wallet) Export(name, path string) error {
+	account, err := w.signingAccount(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	data, err := os.ReadFile(w.KeyPath(account.Name))
+	if err != nil {
+		return fmt.Errorf("failed to read account %s: %w", account.Name, err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+// Rename gives an account a new name, keeping it the default if it was
+func (w *Wallet) Rename(oldName, newName string) error {
+	if !w.Has(oldName) {
+		return fmt.Errorf("%w %q in wallet %s", ErrNoAccount, oldName, w.dir)
+	}
+	if err := w.checkNewName(newName); err != nil {
+		return err
+	}
+	if watched := w.watched(oldName); watched != nil {
+		watched.Name = newName
+		return w.save()
+	}
+	if err := os.Rename(w.KeyPath(oldName), w.KeyPath(newName)); err != nil {
+		return fmt.Errorf("failed to rename account %s: %w", oldName, err)
+	}
+	if w.config.Default == oldName {
+		return w.SetDefault(newName)
+	}
+	return nil
+}
+
+// SetDefault makes the named account the one used when no account is given
+func (w *Wallet) SetDefault(name string) error {
+	if _, err := w.signingAccount(name); err != nil {
+		return err
+	}
+	w.config.Default = name
+	return w.save()
+}
+
+// added finishes adding an account, making it the default of an empty wallet
+func (w *Wallet) added(name string) (*Account, error) {
+	if _, err := w.signingAccount(w.config.Default); err != nil {
+		if err := w.SetDefault(name); err != nil {
+			return nil, err
+		}
+	}
+	return w.Account(name)
+}
+
+func (w *Wallet) checkNewName(name string) error {
+	if !accountNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid account name %q (letters, digits, '.', '_' and '-', at most 32)", name)
+	}
+	if LooksLikeAddress(name) {
+		return fmt.Errorf("account name %q looks like an address", name)
+	}
+	if w.Has(name) {
+		return fmt.Errorf("account %q already exists in wallet %s", name, w.dir)
+	}
+	return nil
+}
+
+func (w *Wallet) checkNewAddress(address []byte) error {
+	accounts, err := w.Accounts()
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		if account.Address.Equal(address) {
+			return fmt.Errorf("address %s is already account %q", account.Address, account.Name)
+		}
+	}
+	return nil
+}
+
+func (w *Wallet) createDir() error {
+	if err := os.MkdirAll(filepath.Join(w.dir, walletKeysDir), 0700); err != nil {
+		return fmt.Errorf("failed to create wallet %s: %w", w.dir, err)
+	}
+	return nil
+}
+
+func (w *Wallet) save() error {
+	data, err := json.MarshalIndent(&w.config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode wallet: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(w.dir, walletConfigFile), data)
+}
+
+// ParsePrivateKey decodes a hex private key of the given scheme: the 32-byte
+// scalar for P-256, the 32-byte seed for Ed25519
+func ParsePrivateKey(scheme blockchain.SignatureScheme, s string) (Signer, error) {
+	secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key hex: %w", err)
+	}
+	return signerFromSecret(scheme, secret)
+}
+
+// PrivateKeyHex encodes the private key of a signer as ParsePrivateKey reads it
+func PrivateKeyHex(signer Signer) (string, error) {
+	secret, err := signerSecret(signer)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
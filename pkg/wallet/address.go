@@ -0,0 +1,153 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// Network is the human-readable prefix that tells which chain an address
+// belongs to. A mainnet address pasted into a testnet command is rejected.
+type Network string
+
+const (
+	MainNet Network = "bgo"
+	TestNet Network = "tbgo"
+
+	// NetworkEnv selects the network used to format and parse addresses
+	NetworkEnv = "NETWORK"
+)
+
+var currentNetwork = MainNet
+
+// ParseNetwork accepts a network name ("mainnet", "testnet") or prefix
+func ParseNetwork(name string) (Network, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "main", "mainnet", string(MainNet):
+		return MainNet, nil
+	case "test", "testnet", string(TestNet):
+		return TestNet, nil
+	}
+	return "", fmt.Errorf("unknown network %q (want mainnet or testnet)", name)
+}
+
+// SetNetwork selects the network used by FormatAddress and ParseAddress
+func SetNetwork(network Network) {
+	currentNetwork = network
+}
+
+// CurrentNetwork returns the network addresses are formatted for
+func CurrentNetwork() Network {
+	return currentNetwork
+}
+
+// UseNetworkEnv selects the network named by the NETWORK environment
+// variable, if set
+func UseNetworkEnv() error {
+	name := os.Getenv(NetworkEnv)
+	if name == "" {
+		return nil
+	}
+	network, err := ParseNetwork(name)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", NetworkEnv, err)
+	}
+	SetNetwork(network)
+	return nil
+}
+
+// Address is a raw account address. It prints as a Bech32 string such as
+// bgo1... whose checksum catches mistyped addresses.
+type Address []byte
+
+// String encodes the address for the current network
+func (a Address) String() string {
+	return a.Encode(currentNetwork)
+}
+
+// Encode encodes the address with the prefix of a network. Values too long
+// for Bech32 fall back to hex.
+func (a Address) Encode(network Network) string {
+	s, err := bech32Encode(string(network), a)
+	if err != nil {
+		return hex.EncodeToString(a)
+	}
+	return s
+}
+
+// Bytes returns the raw address
+func (a Address) Bytes() []byte {
+	return []byte(a)
+}
+
+// Equal reports whether two addresses are the same
+func (a Address) Equal(other Address) bool {
+	return bytes.Equal(a, other)
+}
+
+// FormatAddress encodes a raw address for display
+func FormatAddress(address []byte) string {
+	return Address(address).String()
+}
+
+// ParseAddress decodes a Bech32 address of the current network. Legacy
+// 40-hex addresses are still accepted.
+func ParseAddress(s string) (Address, error) {
+	s = strings.TrimSpace(s)
+	if isLegacyAddress(s) {
+		address, _ := hex.DecodeString(s)
+		return address, nil
+	}
+	if !LooksLikeAddress(s) {
+		return nil, fmt.Errorf("%q is not an address", s)
+	}
+
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", s, err)
+	}
+	if Network(hrp) != currentNetwork {
+		return nil, fmt.Errorf("address %q is for network %s, not %s", s, hrp, currentNetwork)
+	}
+	if len(data) != blockchain.AddressLength {
+		return nil, fmt.Errorf("invalid address %q: %d bytes, want %d", s, len(data), blockchain.AddressLength)
+	}
+	return data, nil
+}
+
+// DecodeAddress is ParseAddress for callers that take raw address bytes,
+// such as blockchain.LoadGenesis
+func DecodeAddress(s string) ([]byte, error) {
+	return ParseAddress(s)
+}
+
+// LooksLikeAddress reports whether s has the shape of an address (legacy hex,
+// or a known network prefix and longer than any name), so a typo is
+// reported instead of being looked up as a name
+func LooksLikeAddress(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if isLegacyAddress(s) {
+		return true
+	}
+	if len(s) < 2*blockchain.AddressLength {
+		return false
+	}
+	for _, network := range []Network{MainNet, TestNet} {
+		if strings.HasPrefix(s, string(network)+"1") {
+			return true
+		}
+	}
+	return false
+}
+
+func isLegacyAddress(s string) bool {
+	if len(s) != 2*blockchain.AddressLength {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,97 @@
+package wallet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Test vectors of BIP-173
+var (
+	bech32Valid = []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"11qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqc8247j",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		"?1ezyfcl",
+	}
+	bech32Invalid = []struct {
+		s      string
+		reason string
+	}{
+		{"\x201nwldj5", "prefix character out of range"},
+		{"\x7f1axkwrx", "prefix character out of range"},
+		{"\x801eym55h", "prefix character out of range"},
+		{"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", "overall max length exceeded"},
+		{"pzry9x0s0muk", "no separator"},
+		{"1pzry9x0s0muk", "empty prefix"},
+		{"x1b4n0q5v", "invalid data character"},
+		{"li1dgmt3", "too short checksum"},
+		{"de1lg7wt\xff", "invalid character in checksum"},
+		{"A1G7SGD8", "checksum calculated with upper case prefix"},
+		{"10a06t8", "empty prefix"},
+		{"1qzzfhee", "empty prefix"},
+	}
+)
+
+func TestBech32Vectors(t *testing.T) {
+	for _, s := range bech32Valid {
+		hrp, data, err := bech32Decode(s)
+		if err != nil {
+			t.Errorf("bech32Decode(%q): %v", s, err)
+			continue
+		}
+		encoded, err := bech32Encode(hrp, data)
+		if err != nil {
+			t.Errorf("bech32Encode(%q): %v", hrp, err)
+			continue
+		}
+		if encoded != strings.ToLower(s) {
+			t.Errorf("round trip of %q gave %q", s, encoded)
+		}
+	}
+
+	for _, tt := range bech32Invalid {
+		if _, _, err := bech32Decode(tt.s); err == nil {
+			t.Errorf("bech32Decode(%q) accepted a string with %s", tt.s, tt.reason)
+		}
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	defer SetNetwork(CurrentNetwork())
+	SetNetwork(MainNet)
+
+	raw := bytes.Repeat([]byte{0xab}, 20)
+	mainnet := Address(raw).Encode(MainNet)
+	testnet := Address(raw).Encode(TestNet)
+	short, _ := bech32Encode(string(MainNet), raw[:19])
+	typo := mainnet[:len(mainnet)-1] + string(bech32Charset[(strings.IndexByte(bech32Charset, mainnet[len(mainnet)-1])+1)%32])
+
+	tests := []struct {
+		name string
+		s    string
+		ok   bool
+	}{
+		{"bech32", mainnet, true},
+		{"upper case", strings.ToUpper(mainnet), true},
+		{"legacy hex", "abababababababababababababababababababab", true},
+		{"other network", testnet, false},
+		{"typo", typo, false},
+		{"wrong length", short, false},
+		{"name", "alice", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := ParseAddress(tt.s)
+			if (err == nil) != tt.ok {
+				t.Fatalf("ParseAddress(%q) = %v, want ok=%t", tt.s, err, tt.ok)
+			}
+			if tt.ok && !bytes.Equal(address, raw) {
+				t.Errorf("ParseAddress(%q) = %x, want %x", tt.s, address, raw)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Hierarchical deterministic keys follow BIP-39 for the seed phrase and
+// SLIP-0010 for deriving P-256 child keys from the seed.
+const (
+	// MnemonicBits is the entropy of newly generated seed phrases (24 words)
+	MnemonicBits = 256
+
+	// HardenedOffset marks a path index as hardened (written i' in paths)
+	HardenedOffset uint32 = 0x80000000
+
+	// DefaultAccountPath is the parent path of the addresses of an HD
+	// wallet; address i lives at DefaultAccountPath/i
+	DefaultAccountPath = "m/44'/1'/0'/0"
+
+	// HDWalletVersion is the current version of the HD wallet file format
+	HDWalletVersion = 1
+
+	slip10Curve = "Nist256p1 seed"
+)
+
+// NewMnemonic generates a new random seed phrase
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MnemonicBits)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate entropy: %w", err)
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NormalizeMnemonic collapses whitespace and lower-cases a seed phrase
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
+
+// MnemonicToSeed checks a seed phrase and turns it into a 64-byte seed.
+// The password is the optional BIP-39 passphrase, not the keystore one.
+func MnemonicToSeed(mnemonic, password string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(NormalizeMnemonic(mnemonic), password)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	return seed, nil
+}
+
+// ExtendedKey is a private key together with the chain code needed to
+// derive its children
+type ExtendedKey struct {
+	key       []byte // 32-byte private scalar
+	chainCode []byte
+}
+
+// NewMasterKey derives the root key of a seed
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length %d", len(seed))
+	}
+
+	n := elliptic.P256().Params().N
+	data := seed
+	for {
+		sum := hmacSHA512([]byte(slip10Curve), data)
+		k := new(big.Int).SetBytes(sum[:32])
+		if k.Sign() > 0 && k.Cmp(n) < 0 {
+			return &ExtendedKey{key: sum[:32], chainCode: sum[32:]}, nil
+		}
+		// Invalid scalar: SLIP-0010 retries with the previous output
+		data = sum
+	}
+}
+
+// Child derives the child key at index. Indexes at or above HardenedOffset
+// produce hardened children that cannot be derived from a public key.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	curve := elliptic.P256()
+	n := curve.Params().N
+
+	var data []byte
+	if index >= HardenedOffset {
+		data = append([]byte{0x00}, k.key...)
+	} else {
+		x, y := curve.ScalarBaseMult(k.key)
+		data = elliptic.MarshalCompressed(curve, x, y)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	parent := new(big.Int).SetBytes(k.key)
+	for {
+		sum := hmacSHA512(k.chainCode, data)
+		il := new(big.Int).SetBytes(sum[:32])
+		if il.Cmp(n) < 0 {
+			child := il.Add(il, parent)
+			child.Mod(child, n)
+			if child.Sign() > 0 {
+				return &ExtendedKey{key: child.FillBytes(make([]byte, 32)), chainCode: sum[32:]}, nil
+			}
+		}
+		// Invalid child: SLIP-0010 retries with 0x01 || IR || index
+		data = binary.BigEndian.AppendUint32(append([]byte{0x01}, sum[32:]...), index)
+	}
+}
+
+// Derive walks a path such as "m/44'/1'/0'/0/3" from this key
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key := k
+	for _, index := range indexes {
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// PrivateKey returns the ECDSA key of the extended key
+func (k *ExtendedKey) PrivateKey() *ecdsa.PrivateKey {
+	return p256Key(new(big.Int).SetBytes(k.key)) // Derivation only yields scalars in range
+}
+
+// ParsePath parses a derivation path. Hardened indexes are written with a
+// trailing ' or h.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("derivation path %q must start with m", path)
+	}
+
+	var indexes []uint32
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedOffset {
+			return nil, fmt.Errorf("invalid index %q in derivation path %q", part, path)
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+// AccountPath returns the derivation path of the i-th address of a wallet
+func AccountPath(accountPath string, index int) string {
+	return fmt.Sprintf("%s/%d", accountPath, index)
+}
+
+// DeriveAccount returns the key at accountPath/index for a seed
+func DeriveAccount(seed []byte, accountPath string, index int) (*ecdsa.PrivateKey, error) {
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	key, err := master.Derive(AccountPath(accountPath, index))
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivateKey(), nil
+}
+
+// HDWalletFile is the on-disk format of an HD wallet. The seed phrase is
+// encrypted like a key file (the path is the authenticated data); the
+// derived addresses are kept in clear so they can be listed without the
+// passphrase.
+type HDWalletFile struct {
+	Version   int           `json:"version"`
+	Path      string        `json:"path"`
+	Addresses []string      `json:"addresses"`
+	Crypto    KeyFileCrypto `json:"crypto"`
+}
+
+// NewHDWalletFile encrypts a seed phrase into a new wallet file, deriving
+// its first address
+func NewHDWalletFile(mnemonic, passphrase string) (*HDWalletFile, error) {
+	mnemonic = NormalizeMnemonic(mnemonic)
+	if _, err := MnemonicToSeed(mnemonic, ""); err != nil {
+		return nil, err
+	}
+	crypto, err := sealSecret([]byte(mnemonic), passphrase, []byte(DefaultAccountPath))
+	if err != nil {
+		return nil, err
+	}
+
+	w := &HDWalletFile{
+		Version: HDWalletVersion,
+		Path:    DefaultAccountPath,
+		Crypto:  *crypto,
+	}
+	if _, err := w.DeriveNext(mnemonic); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Mnemonic decrypts the seed phrase of the wallet
+func (w *HDWalletFile) Mnemonic(passphrase string) (string, error) {
+	if w.Version != HDWalletVersion {
+		return "", fmt.Errorf("unsupported HD wallet version %d", w.Version)
+	}
+	secret, err := openSecret(&w.Crypto, passphrase, []byte(w.Path))
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
+
+// Key returns the private key of the wallet's index-th address
+func (w *HDWalletFile) Key(mnemonic string, index int) (*ecdsa.PrivateKey, error) {
+	seed, err := MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	return DeriveAccount(seed, w.Path, index)
+}
+
+// DeriveNext derives the next address of the wallet and records it
+func (w *HDWalletFile) DeriveNext(mnemonic string) ([]byte, error) {
+	priv, err := w.Key(mnemonic, len(w.Addresses))
+	if err != nil {
+		return nil, err
+	}
+	address := PublicKeyToAddress(&priv.PublicKey)
+	w.Addresses = append(w.Addresses, hex.EncodeToString(address))
+	return address, nil
+}
+
+// SaveHDWallet writes a wallet file to path (mode 0600)
+func SaveHDWallet(path string, w *HDWalletFile) error {
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode HD wallet: %w", err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+// LoadHDWallet reads a wallet file without decrypting it
+func LoadHDWallet(path string) (*HDWalletFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HD wallet %s: %w", path, err)
+	}
+	var w HDWalletFile
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, fmt.Errorf("failed to decode HD wallet %s: %w", path, err)
+	}
+	return &w, nil
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,124 @@
+package wallet
+
+import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"testing"
+)
+
+// slip10Vector is one derivation of the SLIP-0010 test vectors for nist256p1
+type slip10Vector struct {
+	seed      string
+	path      string
+	chainCode string
+	private   string
+	public    string // Compressed; empty where only the private key is checked
+}
+
+var slip10Vectors = []slip10Vector{
+	// Test vector 1
+	{"000102030405060708090a0b0c0d0e0f", "m",
+		"beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+		"612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+		"0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8"},
+	{"000102030405060708090a0b0c0d0e0f", "m/0'",
+		"3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11",
+		"6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c",
+		"0384610f5ecffe8fda089363a41f56a5c7ffc1d81b59a612d0d649b2d22355590c"},
+	{"000102030405060708090a0b0c0d0e0f", "m/0'/1",
+		"4187afff1aafa8445010097fb99d23aee9f599450c7bd140b6826ac22ba21d0c",
+		"284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129",
+		"03526c63f8d0b4bbbf9c80df553fe66742df4676b241dabefdef67733e070f6844"},
+	{"000102030405060708090a0b0c0d0e0f", "m/0'/1/2'",
+		"98c7514f562e64e74170cc3cf304ee1ce54d6b6da4f880f313e8204c2a185318",
+		"694596e8a54f252c960eb771a3c41e7e32496d03b954aeb90f61635b8e092aa7",
+		"0359cf160040778a4b14c5f4d7b76e327ccc8c4a6086dd9451b7482b5a4972dda0"},
+	{"000102030405060708090a0b0c0d0e0f", "m/0'/1/2'/2",
+		"ba96f776a5c3907d7fd48bde5620ee374d4acfd540378476019eab70790c63a0",
+		"5996c37fd3dd2679039b23ed6f70b506c6b56b3cb5e424681fb0fa64caf82aaa", ""},
+	{"000102030405060708090a0b0c0d0e0f", "m/0'/1/2'/2/1000000000",
+		"b9b7b82d326bb9cb5b5b121066feea4eb93d5241103c9e7a18aad40f1dde8059",
+		"21c4f269ef0a5fd1badf47eeacebeeaa3de22eb8e5b0adcd0f27dd99d34d0119",
+		"02216cd26d31147f72427a453c443ed2cde8a1e53c9cc44e5ddf739725413fe3f4"},
+	// Test vector 2
+	{"fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542", "m",
+		"96cd4465a9644e31528eda3592aa35eb39a9527769ce1855beafc1b81055e75d",
+		"eaa31c2e46ca2962227cf21d73a7ef0ce8b31c756897521eb6c7b39796633357",
+		"02c9e16154474b3ed5b38218bb0463e008f89ee03e62d22fdcc8014beab25b48fa"},
+	{"fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542", "m/0",
+		"84e9c258bb8557a40e0d041115b376dd55eda99c0042ce29e81ebe4efed9b86a",
+		"d7d065f63a62624888500cdb4f88b6d59c2927fee9e6d0cdff9cad555884df6e",
+		"039b6df4bece7b6c81e2adfeea4bcf5c8c8a6e40ea7ffa3cf6e8494c61a1fc82cc"},
+	// Derivation retry: the first candidate for m/28578'/33941 is invalid
+	{"000102030405060708090a0b0c0d0e0f", "m/28578'",
+		"e94c8ebe30c2250a14713212f6449b20f3329105ea15b652ca5bdfc68f6c65c2",
+		"06f0db126f023755d0b8d86d4591718a5210dd8d024e3e14b6159d63f53aa669", ""},
+	{"000102030405060708090a0b0c0d0e0f", "m/28578'/33941",
+		"9e87fe95031f14736774cd82f25fd885065cb7c358c1edf813c72af535e83071",
+		"092154eed4af83e078ff9b84322015aefe5769e31270f62c3f66c33888335f3a", ""},
+	// Seed retry: the first master key candidate is invalid
+	{"a7305bc8df8d0951f0cb224c0e95d7707cbdf2c6ce7e8d481fec69c7ff5e9446", "m",
+		"7762f9729fed06121fd13f326884c82f59aa95c57ac492ce8c9654e60efd130c",
+		"3b8c18469a4634517d6d0b65448f8e6c62091b45540a1743c5846be55d47d88f", ""},
+}
+
+func TestSLIP10P256Vectors(t *testing.T) {
+	for _, v := range slip10Vectors {
+		t.Run(v.seed[:8]+"/"+v.path, func(t *testing.T) {
+			seed, _ := hex.DecodeString(v.seed)
+			master, err := NewMasterKey(seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := master.Derive(v.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(key.chainCode); got != v.chainCode {
+				t.Errorf("chain code %s, want %s", got, v.chainCode)
+			}
+			if got := hex.EncodeToString(key.key); got != v.private {
+				t.Errorf("private key %s, want %s", got, v.private)
+			}
+			if v.public != "" {
+				priv := key.PrivateKey()
+				public := elliptic.MarshalCompressed(priv.Curve, priv.X, priv.Y)
+				if got := hex.EncodeToString(public); got != v.public {
+					t.Errorf("public key %s, want %s", got, v.public)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want []uint32
+		ok   bool
+	}{
+		{"m", nil, true},
+		{"m/44'/1'/0'/0/3", []uint32{44 + HardenedOffset, 1 + HardenedOffset, HardenedOffset, 0, 3}, true},
+		{"m/0h/1", []uint32{HardenedOffset, 1}, true},
+		{"44'/0", nil, false},
+		{"m/x", nil, false},
+		{"m/2147483648", nil, false}, // Hardened indexes must use the ' suffix
+	}
+	for _, tt := range tests {
+		got, err := ParsePath(tt.path)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParsePath(%q) error = %v, want ok=%t", tt.path, err, tt.ok)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("ParsePath(%q) = %v, want %v", tt.path, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParsePath(%q) = %v, want %v", tt.path, got, tt.want)
+				break
+			}
+		}
+	}
+}
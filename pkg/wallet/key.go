@@ -4,7 +4,8 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-	"crypto/sha256"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
 )
 
 func GenerateKeyPair() (*ecdsa.PrivateKey, error) {
@@ -12,7 +13,5 @@ func GenerateKeyPair() (*ecdsa.PrivateKey, error) {
 }
 
 func PublicKeyToAddress(pubKey *ecdsa.PublicKey) []byte {
-	pubBytes := append(pubKey.X.Bytes(), pubKey.Y.Bytes()...)
-	hash := sha256.Sum256(pubBytes)
-	return hash[:20]
+	return blockchain.AddressFromPublicKey(pubKey)
 }
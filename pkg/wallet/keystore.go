@@ -0,0 +1,465 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KeystoreVersion is the current version of the encrypted key file format
+const KeystoreVersion = 1
+
+// Default scrypt cost parameters for new key files (128 MiB, ~0.5s)
+const (
+	ScryptN = 1 << 17
+	ScryptR = 8
+	ScryptP = 1
+
+	scryptKeyLen = 32 // AES-256
+	saltLength   = 32
+)
+
+// Bounds on the scrypt parameters accepted from a key file. Files from
+// elsewhere may be stronger or weaker than ours, but a crafted file must not
+// be able to make opening it take unbounded memory or CPU, nor to protect a
+// key with a trivial cost.
+const (
+	minScryptN      = 1 << 14
+	maxScryptN      = 1 << 18
+	maxScryptR      = 16
+	maxScryptP      = 16
+	maxScryptMemory = 256 << 20 // 128 * N * R bytes
+	minSaltLength   = 16
+)
+
+var (
+	// ErrWrongPassphrase is returned when a key file cannot be decrypted
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted key file")
+
+	// ErrPlaintextKey is returned when a legacy unencrypted key file is
+	// loaded as a keystore; it must be migrated first
+	ErrPlaintextKey = errors.New("key file is not encrypted")
+)
+
+// KeyFile is the on-disk format of an encrypted private key:
+//
+//	{
+//	  "version": 1,
+//	  "address": "<40-hex>",
+//	  "crypto": {
+//	    "kdf": "scrypt",
+//	    "kdfparams": {"n": 131072, "r": 8, "p": 1, "salt": "<hex>"},
+//	    "cipher": "aes-256-gcm",
+//	    "nonce": "<hex>",
+//	    "ciphertext": "<hex>"
+//	  }
+//	}
+//
+// The ciphertext is the 32-byte private scalar (the seed for Ed25519 keys)
+// sealed with a key derived from the passphrase. The address is authenticated as additional data so it
+// cannot be swapped without detection.
+//
+// A key an account was rotated to also records that account's address in
+// "account", since it differs from the address derived from the key.
+type KeyFile struct {
+	Version int                        `json:"version"`
+	Address string                     `json:"address"`
+	Account string                     `json:"account,omitempty"` // Set after a key rotation
+	Scheme  blockchain.SignatureScheme `json:"scheme,omitempty"`  // Empty for P-256
+	Crypto  KeyFileCrypto              `json:"crypto"`
+}
+
+// AccountAddress returns the hex address the key signs for
+func (k *KeyFile) AccountAddress() string {
+	if k.Account != "" {
+		return k.Account
+	}
+	return k.Address
+}
+
+// additionalData returns the addresses authenticated along with the key
+func (k *KeyFile) additionalData() []byte {
+	if k.Account == "" {
+		return []byte(k.Address)
+	}
+	return []byte(k.Address + ":" + k.Account)
+}
+
+// KeyFileCrypto holds the KDF and cipher parameters of a key file
+type KeyFileCrypto struct {
+	KDF        string       `json:"kdf"`
+	KDFParams  ScryptParams `json:"kdfparams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      string       `json:"nonce"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+// ScryptParams are the scrypt parameters used to derive the encryption key
+type ScryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt string `json:"salt"`
+}
+
+// LegacyKeyData is the plaintext key file format written by earlier versions
+// of the CLI (user_key.json, alice_key.json, ...)
+type LegacyKeyData struct {
+	PrivateKey string `json:"private_key"`
+	PublicKeyX string `json:"public_key_x"`
+	PublicKeyY string `json:"public_key_y"`
+}
+
+// EncryptKey seals an ECDSA P-256 private key with a passphrase
+func EncryptKey(priv *ecdsa.PrivateKey, passphrase string) (*KeyFile, error) {
+	return EncryptSigner(NewP256Signer(priv), passphrase)
+}
+
+// EncryptSigner seals the private key of a signer with a passphrase
+func EncryptSigner(signer Signer, passphrase string) (*KeyFile, error) {
+	keyFile := &KeyFile{
+		Version: KeystoreVersion,
+		Scheme:  signer.Scheme(),
+	}
+	if rotated, ok := signer.(*rotatedSigner); ok {
+		keyFile.Account = hex.EncodeToString(rotated.account)
+		signer = rotated.Signer
+	}
+	keyFile.Address = hex.EncodeToString(signer.Address())
+
+	secret, err := signerSecret(signer)
+	if err != nil {
+		return nil, err
+	}
+	crypto, err := sealSecret(secret, passphrase, keyFile.additionalData())
+	if err != nil {
+		return nil, err
+	}
+	keyFile.Crypto = *crypto
+	return keyFile, nil
+}
+
+// DecryptKey opens a P-256 key file with a passphrase
+func DecryptKey(keyFile *KeyFile, passphrase string) (*ecdsa.PrivateKey, error) {
+	signer, err := DecryptSigner(keyFile, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	p256, ok := signer.(*P256Signer)
+	if !ok {
+		return nil, fmt.Errorf("key file holds a %s key, not p256", SchemeName(keyFile.Scheme))
+	}
+	return p256.PrivateKey(), nil
+}
+
+// DecryptSigner opens a key file of any scheme with a passphrase
+func DecryptSigner(keyFile *KeyFile, passphrase string) (Signer, error) {
+	if keyFile.Version != KeystoreVersion {
+		return nil, fmt.Errorf("unsupported key file version %d", keyFile.Version)
+	}
+	secret, err := openSecret(&keyFile.Crypto, passphrase, keyFile.additionalData())
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := signerFromSecret(keyFile.Scheme, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if address := hex.EncodeToString(signer.Address()); address != keyFile.Address {
+		return nil, fmt.Errorf("key file address %s does not match key %s", keyFile.Address, address)
+	}
+	if keyFile.Account != "" {
+		account, err := hex.DecodeString(keyFile.Account)
+		if err != nil || len(account) != blockchain.AddressLength {
+			return nil, fmt.Errorf("invalid account address %q in key file", keyFile.Account)
+		}
+		return RotatedSigner(signer, account), nil
+	}
+	return signer, nil
+}
+
+// SaveKeystore encrypts a P-256 private key and writes it to path (mode 0600)
+func SaveKeystore(path string, priv *ecdsa.PrivateKey, passphrase string) error {
+	return SaveSigner(path, NewP256Signer(priv), passphrase)
+}
+
+// SaveSigner encrypts the key of a signer and writes it to path (mode 0600)
+func SaveSigner(path string, signer Signer, passphrase string) error {
+	keyFile, err := EncryptSigner(signer, passphrase)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(keyFile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode key file: %w", err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+// LoadKeystore reads and decrypts the P-256 key file at path
+func LoadKeystore(path string, passphrase string) (*ecdsa.PrivateKey, error) {
+	keyFile, err := ReadKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptKey(keyFile, passphrase)
+}
+
+// LoadSigner reads and decrypts the key file at path, whatever its scheme
+func LoadSigner(path string, passphrase string) (Signer, error) {
+	keyFile, err := ReadKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptSigner(keyFile, passphrase)
+}
+
+// ReadKeyFile reads an encrypted key file without decrypting it
+func ReadKeyFile(path string) (*KeyFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s: %w", path, err)
+	}
+
+	if IsLegacyKeyFile(data) {
+		return nil, fmt.Errorf("%s: %w", path, ErrPlaintextKey)
+	}
+	var keyFile KeyFile
+	if err := json.Unmarshal(data, &keyFile); err != nil {
+		return nil, fmt.Errorf("failed to decode key file %s: %w", path, err)
+	}
+	return &keyFile, nil
+}
+
+// IsLegacyKeyFile reports whether data is a plaintext LegacyKeyData file
+func IsLegacyKeyFile(data []byte) bool {
+	var legacy LegacyKeyData
+	return json.Unmarshal(data, &legacy) == nil && legacy.PrivateKey != ""
+}
+
+// LoadLegacyKey reads a plaintext LegacyKeyData key file
+func LoadLegacyKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s: %w", path, err)
+	}
+
+	var legacy LegacyKeyData
+	if err := json.Unmarshal(data, &legacy); err != nil || legacy.PrivateKey == "" {
+		return nil, fmt.Errorf("%s is not a plaintext key file", path)
+	}
+	secret, err := hex.DecodeString(legacy.PrivateKey)
+	if err != nil || len(secret) == 0 || len(secret) > 32 {
+		return nil, fmt.Errorf("invalid private key in %s", path)
+	}
+	priv, err := privateKeyFromScalar(secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key in %s: %w", path, err)
+	}
+	return priv, nil
+}
+
+// MigrateKeyFile replaces a plaintext key file with an encrypted one in place.
+// It returns the address of the migrated key.
+func MigrateKeyFile(path string, passphrase string) ([]byte, error) {
+	priv, err := LoadLegacyKey(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := SaveKeystore(path, priv, passphrase); err != nil {
+		return nil, err
+	}
+	return PublicKeyToAddress(&priv.PublicKey), nil
+}
+
+// sealSecret encrypts a secret with a passphrase, authenticating ad with it
+func sealSecret(secret []byte, passphrase string, ad []byte) (*KeyFileCrypto, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	params := ScryptParams{N: ScryptN, R: ScryptR, P: ScryptP, Salt: hex.EncodeToString(salt)}
+
+	aead, err := newKeyCipher(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return &KeyFileCrypto{
+		KDF:        "scrypt",
+		KDFParams:  params,
+		Cipher:     "aes-256-gcm",
+		Nonce:      hex.EncodeToString(nonce),
+		Ciphertext: hex.EncodeToString(aead.Seal(nil, nonce, secret, ad)),
+	}, nil
+}
+
+// openSecret decrypts a secret sealed by sealSecret
+func openSecret(crypto *KeyFileCrypto, passphrase string, ad []byte) ([]byte, error) {
+	if crypto.KDF != "scrypt" || crypto.Cipher != "aes-256-gcm" {
+		return nil, fmt.Errorf("unsupported key file crypto %s/%s", crypto.KDF, crypto.Cipher)
+	}
+
+	nonce, err := hex.DecodeString(crypto.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(crypto.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	aead, err := newKeyCipher(passphrase, crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length %d", len(nonce))
+	}
+	secret, err := aead.Open(nil, nonce, ciphertext, ad)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return secret, nil
+}
+
+// check rejects scrypt parameters outside the accepted bounds
+func (p ScryptParams) check() error {
+	if p.N < minScryptN || p.N > maxScryptN || p.N&(p.N-1) != 0 {
+		return fmt.Errorf("scrypt n %d must be a power of two between %d and %d", p.N, minScryptN, maxScryptN)
+	}
+	if p.R < 1 || p.R > maxScryptR {
+		return fmt.Errorf("scrypt r %d must be between 1 and %d", p.R, maxScryptR)
+	}
+	if p.P < 1 || p.P > maxScryptP {
+		return fmt.Errorf("scrypt p %d must be between 1 and %d", p.P, maxScryptP)
+	}
+	if 128*p.N*p.R > maxScryptMemory {
+		return fmt.Errorf("scrypt n %d and r %d need more than %d MiB", p.N, p.R, maxScryptMemory>>20)
+	}
+	return nil
+}
+
+// newKeyCipher derives the AES-256-GCM cipher for a passphrase
+func newKeyCipher(passphrase string, params ScryptParams) (cipher.AEAD, error) {
+	if err := params.check(); err != nil {
+		return nil, err
+	}
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	if len(salt) < minSaltLength {
+		return nil, fmt.Errorf("salt of %d bytes is too short", len(salt))
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// privateKeyFromScalar rebuilds a P-256 private key from its secret scalar,
+// which must lie in [1, N-1]
+func privateKeyFromScalar(secret []byte) (*ecdsa.PrivateKey, error) {
+	d := new(big.Int).SetBytes(secret)
+	if d.Sign() == 0 || d.Cmp(elliptic.P256().Params().N) >= 0 {
+		return nil, fmt.Errorf("p256 private key is out of range")
+	}
+	return p256Key(d), nil
+}
+
+// p256Key returns the P-256 key with a scalar already known to be in range
+func p256Key(d *big.Int) *ecdsa.PrivateKey {
+	curve := elliptic.P256()
+	priv := &ecdsa.PrivateKey{D: d}
+	priv.PublicKey.Curve = curve
+	priv.PublicKey.X, priv.PublicKey.Y = curve.ScalarBaseMult(priv.D.FillBytes(make([]byte, 32)))
+	return priv
+}
+
+// signerSecret returns the 32 bytes a key file stores for a signer's key: the
+// private scalar for P-256, the seed for Ed25519
+func signerSecret(signer Signer) ([]byte, error) {
+	switch s := signer.(type) {
+	case *rotatedSigner:
+		return signerSecret(s.Signer)
+	case *P256Signer:
+		return s.key.D.FillBytes(make([]byte, 32)), nil
+	case *Ed25519Signer:
+		return s.key.Seed(), nil
+	default:
+		return nil, fmt.Errorf("cannot store %T keys", signer)
+	}
+}
+
+// signerFromSecret rebuilds a signer from the bytes returned by signerSecret
+func signerFromSecret(scheme blockchain.SignatureScheme, secret []byte) (Signer, error) {
+	switch scheme {
+	case blockchain.SchemeP256:
+		if len(secret) == 0 || len(secret) > 32 {
+			return nil, fmt.Errorf("invalid p256 private key length %d", len(secret))
+		}
+		priv, err := privateKeyFromScalar(secret)
+		if err != nil {
+			return nil, err
+		}
+		return NewP256Signer(priv), nil
+	case blockchain.SchemeEd25519:
+		if len(secret) != ed25519.SeedSize {
+			return nil, fmt.Errorf("invalid ed25519 seed length %d", len(secret))
+		}
+		return NewEd25519Signer(ed25519.NewKeyFromSeed(secret)), nil
+	default:
+		return nil, fmt.Errorf("unknown signature scheme %q", scheme)
+	}
+}
+
+// writeFileAtomic writes data to a temporary file and renames it over path,
+// so an interrupted write never leaves a truncated key file behind
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	for _, scheme := range []blockchain.SignatureScheme{blockchain.SchemeP256, blockchain.SchemeEd25519} {
+		t.Run(SchemeName(scheme), func(t *testing.T) {
+			signer, err := GenerateSigner(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(t.TempDir(), "key.json")
+			if err := SaveSigner(path, signer, "correct horse"); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, err := LoadSigner(path, "correct horse")
+			if err != nil {
+				t.Fatalf("LoadSigner: %v", err)
+			}
+			if loaded.Scheme() != scheme || !bytes.Equal(loaded.PublicKey(), signer.PublicKey()) {
+				t.Errorf("loaded %s key %x, want %s key %x",
+					SchemeName(loaded.Scheme()), loaded.PublicKey(), SchemeName(scheme), signer.PublicKey())
+			}
+
+			if _, err := LoadSigner(path, "wrong horse"); !errors.Is(err, ErrWrongPassphrase) {
+				t.Errorf("wrong passphrase: got %v, want ErrWrongPassphrase", err)
+			}
+		})
+	}
+}
+
+func TestKeystoreRejectsTamperedAddress(t *testing.T) {
+	signer, err := GenerateSigner(blockchain.SchemeP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile, err := EncryptSigner(signer, "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile.Address = "0000000000000000000000000000000000000000"
+	if _, err := DecryptSigner(keyFile, "pw"); err == nil {
+		t.Error("decrypted a key file whose address was swapped")
+	}
+}
+
+func TestScryptParamsBounds(t *testing.T) {
+	salt := "00112233445566778899aabbccddeeff"
+	tests := []struct {
+		name   string
+		params ScryptParams
+		ok     bool
+	}{
+		{"default", ScryptParams{N: ScryptN, R: ScryptR, P: ScryptP, Salt: salt}, true},
+		{"n too small", ScryptParams{N: 1 << 10, R: 8, P: 1, Salt: salt}, false},
+		{"n too large", ScryptParams{N: 1 << 30, R: 8, P: 1, Salt: salt}, false},
+		{"n not a power of two", ScryptParams{N: 100000, R: 8, P: 1, Salt: salt}, false},
+		{"r zero", ScryptParams{N: ScryptN, R: 0, P: 1, Salt: salt}, false},
+		{"r too large", ScryptParams{N: 1 << 14, R: 1 << 20, P: 1, Salt: salt}, false},
+		{"memory too large", ScryptParams{N: 1 << 18, R: 16, P: 1, Salt: salt}, false},
+		{"p zero", ScryptParams{N: ScryptN, R: 8, P: 0, Salt: salt}, false},
+		{"p too large", ScryptParams{N: ScryptN, R: 8, P: 1 << 20, Salt: salt}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.check(); (err == nil) != tt.ok {
+				t.Errorf("check() = %v, want ok=%t", err, tt.ok)
+			}
+		})
+	}
+
+	// A file with a short salt fails before any key derivation
+	if _, err := newKeyCipher("pw", ScryptParams{N: ScryptN, R: 8, P: 1, Salt: "00"}); err == nil {
+		t.Error("accepted a 1-byte salt")
+	}
+}
+
+func TestPrivateKeyFromScalarRange(t *testing.T) {
+	n := elliptic.P256().Params().N
+	tests := []struct {
+		name   string
+		scalar []byte
+		ok     bool
+	}{
+		{"one", []byte{1}, true},
+		{"n-1", new(big.Int).Sub(n, big.NewInt(1)).Bytes(), true},
+		{"zero", []byte{0}, false},
+		{"n", n.Bytes(), false},
+		{"n+1", new(big.Int).Add(n, big.NewInt(1)).Bytes(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := privateKeyFromScalar(tt.scalar); (err == nil) != tt.ok {
+				t.Errorf("privateKeyFromScalar(%x) = %v, want ok=%t", tt.scalar, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestLoadLegacyKeyRejectsZero(t *testing.T) {
+	for _, key := range []string{"00", "0000000000000000000000000000000000000000000000000000000000000000"} {
+		data, _ := json.Marshal(LegacyKeyData{PrivateKey: key})
+		path := filepath.Join(t.TempDir(), "legacy.json")
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadLegacyKey(path); err == nil {
+			t.Errorf("LoadLegacyKey accepted private key %q", key)
+		}
+	}
+}
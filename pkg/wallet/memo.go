@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// memoDomain separates memo encryption keys from every other use of the
+// shared secret
+const memoDomain = "blockchain-go/memo"
+
+const (
+	memoKeyLength   = 65 // Uncompressed ephemeral P-256 public key
+	memoNonceLength = 12
+)
+
+// ErrMemoNotForKey is returned when a memo was not encrypted to the key
+// trying to open it
+var ErrMemoNotForKey = errors.New("memo is not encrypted to this key")
+
+// EncryptMemo seals a memo so that only the holder of the receiver's P-256
+// key can read it. An ephemeral key agrees on a secret with the receiver's
+// key (ECDH), and the memo is sealed with AES-256-GCM under a key derived
+// from it. The result goes into Transaction.Memo.
+func EncryptMemo(scheme blockchain.SignatureScheme, receiverKey []byte, memo []byte) ([]byte, error) {
+	if scheme != blockchain.SchemeP256 {
+		return nil, fmt.Errorf("receiver signs with %s; encrypted memos need a p256 key", SchemeName(scheme))
+	}
+	if len(memo) == 0 || len(memo) > blockchain.MaxMemoLength {
+		return nil, fmt.Errorf("memo must be 1 to %d bytes, got %d", blockchain.MaxMemoLength, len(memo))
+	}
+	pub, err := blockchain.ParsePublicKey(receiverKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receiver key: %w", err)
+	}
+	receiver, err := pub.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("invalid receiver key: %w", err)
+	}
+
+	ephemeral, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+	shared, err := ephemeral.ECDH(receiver)
+	if err != nil {
+		return nil, fmt.Errorf("key agreement failed: %w", err)
+	}
+	aead, err := memoCipher(shared, ephemeral.PublicKey().Bytes(), receiver.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	out := append([]byte{blockchain.MemoVersion}, ephemeral.PublicKey().Bytes()...)
+	nonce := make([]byte, memoNonceLength)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	header := len(out)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, memo, out[:header]), nil
+}
+
+// DecryptMemo opens a memo encrypted with EncryptMemo to signer's key
+func DecryptMemo(signer Signer, memo []byte) ([]byte, error) {
+	if rotated, ok := signer.(*rotatedSigner); ok {
+		signer = rotated.Signer
+	}
+	p256, ok := signer.(*P256Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s keys cannot receive encrypted memos", SchemeName(signer.Scheme()))
+	}
+	if len(memo) < blockchain.MemoOverhead || memo[0] != blockchain.MemoVersion {
+		return nil, fmt.Errorf("invalid encrypted memo")
+	}
+	priv, err := p256.PrivateKey().ECDH()
+	if err != nil {
+		return nil, err
+	}
+
+	header := 1 + memoKeyLength
+	ephemeral, err := ecdh.P256().NewPublicKey(memo[1:header])
+	if err != nil {
+		return nil, fmt.Errorf("invalid memo key: %w", err)
+	}
+	shared, err := priv.ECDH(ephemeral)
+	if err != nil {
+		return nil, fmt.Errorf("key agreement failed: %w", err)
+	}
+	aead, err := memoCipher(shared, ephemeral.Bytes(), priv.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	nonce := memo[header : header+memoNonceLength]
+	plaintext, err := aead.Open(nil, nonce, memo[header+memoNonceLength:], memo[:header])
+	if err != nil {
+		return nil, ErrMemoNotForKey
+	}
+	return plaintext, nil
+}
+
+// memoCipher derives the AES-256-GCM cipher of a memo from the ECDH secret
+// and both public keys
+func memoCipher(shared, ephemeral, receiver []byte) (cipher.AEAD, error) {
+	data := append([]byte(memoDomain), 0)
+	data = append(data, shared...)
+	data = append(data, ephemeral...)
+	data = append(data, receiver...)
+	key := sha256.Sum256(data)
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+func TestMemoRoundTrip(t *testing.T) {
+	receiver, err := GenerateSigner(blockchain.SchemeP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := GenerateSigner(blockchain.SchemeP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, plaintext := range [][]byte{[]byte("invoice 42"), bytes.Repeat([]byte{'x'}, blockchain.MaxMemoLength)} {
+		memo, err := EncryptMemo(receiver.Scheme(), receiver.PublicKey(), plaintext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(memo) != blockchain.MemoOverhead+len(plaintext) {
+			t.Errorf("memo of %d bytes is %d bytes, want %d", len(plaintext), len(memo), blockchain.MemoOverhead+len(plaintext))
+		}
+		tx := &blockchain.Transaction{Sender: other.Address(), Receiver: receiver.Address(), Amount: 1, Memo: memo}
+		if err := SignTransactionWith(tx, other); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Validate(); err != nil {
+			t.Errorf("node rejects the memo: %v", err)
+		}
+
+		got, err := DecryptMemo(receiver, memo)
+		if err != nil {
+			t.Fatalf("DecryptMemo() = %v", err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("DecryptMemo() = %q, want %q", got, plaintext)
+		}
+
+		if _, err := DecryptMemo(other, memo); !errors.Is(err, ErrMemoNotForKey) {
+			t.Errorf("DecryptMemo() with another key = %v, want ErrMemoNotForKey", err)
+		}
+	}
+}
+
+func TestMemoRejects(t *testing.T) {
+	receiver, err := GenerateSigner(blockchain.SchemeP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Key, err := GenerateSigner(blockchain.SchemeEd25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := EncryptMemo(ed25519Key.Scheme(), ed25519Key.PublicKey(), []byte("hi")); err == nil {
+		t.Error("encrypted a memo to an ed25519 key")
+	}
+	if _, err := EncryptMemo(receiver.Scheme(), receiver.PublicKey(), nil); err == nil {
+		t.Error("encrypted an empty memo")
+	}
+	if _, err := EncryptMemo(receiver.Scheme(), receiver.PublicKey(), make([]byte, blockchain.MaxMemoLength+1)); err == nil {
+		t.Error("encrypted a memo over the size limit")
+	}
+
+	memo, err := EncryptMemo(receiver.Scheme(), receiver.PublicKey(), []byte("hi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Clone(memo)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := DecryptMemo(receiver, tampered); !errors.Is(err, ErrMemoNotForKey) {
+		t.Errorf("DecryptMemo() of a tampered memo = %v, want ErrMemoNotForKey", err)
+	}
+	if _, err := DecryptMemo(receiver, memo[:blockchain.MemoOverhead-1]); err == nil {
+		t.Error("decrypted a truncated memo")
+	}
+}
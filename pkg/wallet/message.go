@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// messageDomain separates message signatures from transaction, proposal and
+// vote signatures: a transaction hash covers its JSON encoding, which can
+// never start with this tag, so a signed message is never a valid
+// transaction signature and the other way around
+const messageDomain = "blockchain-go/message"
+
+// messageSignaturePrefix marks an encoded message signature
+const messageSignaturePrefix = "bgosig1:"
+
+// ErrRotatedKey is returned when a message was signed by a key that no
+// longer signs for the account
+var ErrRotatedKey = errors.New("message was signed by a key the account has rotated away from")
+
+// MessageDigest returns the hash signed for an off-chain message
+func MessageDigest(message []byte) []byte {
+	data := append([]byte(messageDomain), 0)
+	data = binary.BigEndian.AppendUint64(data, uint64(len(message)))
+	data = append(data, message...)
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// MessageSignature proves that the holder of an address's key signed a
+// message. It is exchanged as a single line (see Encode) and carries
+// everything needed to verify it except the message itself and the key the
+// chain currently authorizes for the address.
+type MessageSignature struct {
+	Address   string `json:"address"`
+	Scheme    string `json:"scheme"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+// SignMessage signs an off-chain message with a key. A signer of a rotated
+// account signs with its new key, which the signature carries.
+func SignMessage(signer Signer, message []byte) (*MessageSignature, error) {
+	sig, err := signer.Sign(MessageDigest(message))
+	if err != nil {
+		return nil, err
+	}
+	return &MessageSignature{
+		Address:   FormatAddress(signer.Address()),
+		Scheme:    SchemeName(signer.Scheme()),
+		PublicKey: hex.EncodeToString(signer.PublicKey()),
+		Signature: hex.EncodeToString(sig),
+	}, nil
+}
+
+// VerifyMessage checks that sig is a signature of message by the key that
+// signs for the address it names, and returns that address. current is that
+// key as recorded on chain (see GetAccount), or nil if the chain has not seen
+// it yet, in which case only the key the address was derived from is
+// accepted. A key the account has rotated away from fails with ErrRotatedKey.
+func VerifyMessage(message []byte, sig *MessageSignature, current *blockchain.AuthorizedKey) (Address, error) {
+	address, err := ParseAddress(sig.Address)
+	if err != nil {
+		return nil, err
+	}
+	scheme, err := ParseScheme(sig.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := hex.DecodeString(sig.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	signature, err := hex.DecodeString(sig.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	verifier, err := blockchain.ParseVerifier(scheme, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case current != nil && !current.Authorizes(scheme, pubKey):
+		if address.Equal(verifier.Address()) {
+			return nil, fmt.Errorf("%w at height %d", ErrRotatedKey, current.Height)
+		}
+		return nil, fmt.Errorf("public key does not sign for %s", address)
+	case current == nil && !address.Equal(verifier.Address()):
+		return nil, fmt.Errorf("public key belongs to %s, not %s", FormatAddress(verifier.Address()), address)
+	}
+	if err := verifier.Verify(MessageDigest(message), signature); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// Encode returns the signature as a portable single-line blob
+func (s *MessageSignature) Encode() string {
+	data, _ := json.Marshal(s)
+	return messageSignaturePrefix + base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeMessageSignature parses a blob returned by MessageSignature.Encode
+func DecodeMessageSignature(blob string) (*MessageSignature, error) {
+	blob = strings.TrimSpace(blob)
+	if !strings.HasPrefix(blob, messageSignaturePrefix) {
+		return nil, fmt.Errorf("not a message signature (expected %s...)", messageSignaturePrefix)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(blob, messageSignaturePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid message signature encoding: %w", err)
+	}
+	var sig MessageSignature
+	if err := json.Unmarshal(data, &sig); err != nil {
+		return nil, fmt.Errorf("invalid message signature: %w", err)
+	}
+	return &sig, nil
+}
@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+func TestMessageSignature(t *testing.T) {
+	for _, scheme := range []blockchain.SignatureScheme{blockchain.SchemeP256, blockchain.SchemeEd25519} {
+		t.Run(SchemeName(scheme), func(t *testing.T) {
+			signer, err := GenerateSigner(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			other, err := GenerateSigner(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			message := []byte("I own this address")
+			sig, err := SignMessage(signer, message)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := DecodeMessageSignature(sig.Encode())
+			if err != nil {
+				t.Fatal(err)
+			}
+			address, err := VerifyMessage(message, decoded, nil)
+			if err != nil {
+				t.Fatalf("VerifyMessage() = %v", err)
+			}
+			if !address.Equal(signer.Address()) {
+				t.Errorf("VerifyMessage() = %s, want %s", address, FormatAddress(signer.Address()))
+			}
+
+			if _, err := VerifyMessage([]byte("I own that address"), sig, nil); err == nil {
+				t.Error("verified a signature of another message")
+			}
+			claimed := *sig
+			claimed.Address = FormatAddress(other.Address())
+			if _, err := VerifyMessage(message, &claimed, nil); err == nil {
+				t.Error("verified a signature claiming another address")
+			}
+		})
+	}
+}
+
+func TestVerifyMessageRotatedAccount(t *testing.T) {
+	account, err := GenerateSigner(blockchain.SchemeP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := GenerateSigner(blockchain.SchemeEd25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := GenerateSigner(blockchain.SchemeEd25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := &blockchain.AuthorizedKey{Scheme: account.Scheme(), PublicKey: account.PublicKey()}
+	rotated := &blockchain.AuthorizedKey{Scheme: newKey.Scheme(), PublicKey: newKey.PublicKey(), Height: 7}
+	message := []byte("I own this address")
+
+	tests := []struct {
+		name    string
+		signer  Signer
+		current *blockchain.AuthorizedKey
+		ok      bool
+	}{
+		{"original key before the chain saw it", account, nil, true},
+		{"original key", account, original, true},
+		{"old key after rotation", account, rotated, false},
+		{"new key", RotatedSigner(newKey, account.Address()), rotated, true},
+		{"new key before the rotation", RotatedSigner(newKey, account.Address()), original, false},
+		{"new key unknown to the chain", RotatedSigner(newKey, account.Address()), nil, false},
+		{"unrelated key", RotatedSigner(other, account.Address()), rotated, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := SignMessage(tt.signer, message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			address, err := VerifyMessage(message, sig, tt.current)
+			if (err == nil) != tt.ok {
+				t.Fatalf("VerifyMessage() = %v, want ok=%t", err, tt.ok)
+			}
+			if err == nil && !address.Equal(account.Address()) {
+				t.Errorf("VerifyMessage() = %s, want %s", address, FormatAddress(account.Address()))
+			}
+		})
+	}
+
+	sig, err := SignMessage(account, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyMessage(message, sig, rotated); !errors.Is(err, ErrRotatedKey) {
+		t.Errorf("VerifyMessage() with the old key = %v, want ErrRotatedKey", err)
+	}
+}
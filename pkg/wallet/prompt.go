@@ -0,0 +1,64 @@
+package wallet
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// PassphraseEnv lets scripts supply the keystore passphrase non-interactively
+const PassphraseEnv = "WALLET_PASSPHRASE"
+
+// ReadPassphrase prompts for a keystore passphrase without echoing it.
+// When confirm is set the passphrase must be typed twice and be non-empty.
+func ReadPassphrase(prompt string, confirm bool) (string, error) {
+	if passphrase, ok := os.LookupEnv(PassphraseEnv); ok {
+		return passphrase, nil
+	}
+
+	passphrase, err := PromptLine(prompt)
+	if err != nil {
+		return "", err
+	}
+	if !confirm {
+		return passphrase, nil
+	}
+
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase must not be empty")
+	}
+	repeat, err := PromptLine("Repeat passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if repeat != passphrase {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+// PromptLine reads one line from the terminal, hiding the input when stdin
+// is a terminal
+func PromptLine(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		line, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		return string(line), nil
+	}
+
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// stdinReader is shared so piped passphrases are not lost to read-ahead
+var stdinReader = bufio.NewReader(os.Stdin)
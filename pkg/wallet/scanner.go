@@ -0,0 +1,360 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/protoconv"
+	"github.com/nguyentrinhquy1411/blockchain-go/proto"
+)
+
+// scanBatchSize is the number of blocks fetched per request while scanning
+const scanBatchSize = 100
+
+// BlockSource provides committed blocks to a Scanner
+type BlockSource interface {
+	// LatestHeight returns the height of the newest committed block
+	LatestHeight(ctx context.Context) (int, error)
+	// Blocks returns the committed blocks from..to (inclusive), in order
+	Blocks(ctx context.Context, from, to int) ([]*blockchain.Block, error)
+}
+
+// Activity is one transaction touching a scanned address
+type Activity struct {
+	Height    int                `json:"height"`
+	Timestamp int64              `json:"timestamp"`
+	TxHash    string             `json:"tx_hash"`
+	Type      string             `json:"type,omitempty"`
+	From      string             `json:"from"` // Hex, or "genesis"/"consensus" for minted coins
+	To        string             `json:"to,omitempty"`
+	Asset     string             `json:"asset,omitempty"`
+	Amount    float64            `json:"amount"`
+	Fee       float64            `json:"fee,omitempty"`
+	Changes   map[string]float64 `json:"changes,omitempty"` // Net balance change of the address per asset
+	Memo      []byte             `json:"memo,omitempty"`    // Encrypted memo, see DecryptMemo
+}
+
+// Direction describes the activity from the address' point of view: "in"
+// when it gained, "out" when it lost and "self" otherwise
+func (a *Activity) Direction() string {
+	var net float64
+	for _, change := range a.Changes {
+		net += change
+	}
+	switch {
+	case net > 0:
+		return "in"
+	case net < 0:
+		return "out"
+	default:
+		return "self"
+	}
+}
+
+// AddressHistory is the scanned history and balances of one address
+type AddressHistory struct {
+	Balances map[string]float64 `json:"balances"`
+	Activity []*Activity        `json:"activity"`
+}
+
+// ScanState is what a Scanner has learned from the chain so far
+type ScanState struct {
+	Height    int                        `json:"height"`     // Last scanned block, -1 before genesis
+	BlockHash string                     `json:"block_hash"` // Hash of that block, to detect a replaced chain
+	Addresses map[string]*AddressHistory `json:"addresses"`  // Keyed by hex address
+}
+
+// Scanner walks committed blocks and keeps the history and balances of a set
+// of addresses. Its state is saved to a file so each scan only reads the
+// blocks committed since the previous one.
+type Scanner struct {
+	path  string
+	state ScanState
+}
+
+// LoadScanner reads the scanner state saved at path, starting afresh if the
+// file does not exist
+func LoadScanner(path string) (*Scanner, error) {
+	s := &Scanner{path: path}
+	s.reset()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scan state %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to decode scan state %s: %w", path, err)
+	}
+	if s.state.Addresses == nil {
+		s.state.Addresses = make(map[string]*AddressHistory)
+	}
+	return s, nil
+}
+
+// reset forgets everything scanned so far, keeping the watched addresses
+func (s *Scanner) reset() {
+	addresses := make(map[string]*AddressHistory, len(s.state.Addresses))
+	for address := range s.state.Addresses {
+		addresses[address] = newAddressHistory()
+	}
+	s.state = ScanState{Height: -1, Addresses: addresses}
+}
+
+func newAddressHistory() *AddressHistory {
+	return &AddressHistory{Balances: make(map[string]float64)}
+}
+
+// Height returns the last scanned block height, -1 if nothing was scanned
+func (s *Scanner) Height() int {
+	return s.state.Height
+}
+
+// SetAddresses sets the addresses to track. Addresses no longer listed are
+// dropped; if any are new, the next scan starts again from genesis so their
+// history is complete.
+func (s *Scanner) SetAddresses(addresses [][]byte) {
+	wanted := make(map[string]bool, len(addresses))
+	added := false
+	for _, address := range addresses {
+		key := hex.EncodeToString(address)
+		wanted[key] = true
+		if _, ok := s.state.Addresses[key]; !ok {
+			s.state.Addresses[key] = newAddressHistory()
+			added = true
+		}
+	}
+	for key := range s.state.Addresses {
+		if !wanted[key] {
+			delete(s.state.Addresses, key)
+		}
+	}
+	if added {
+		s.reset()
+	}
+}
+
+// History returns the scanned history of an address, or nil if it is not
+// tracked
+func (s *Scanner) History(address []byte) *AddressHistory {
+	return s.state.Addresses[hex.EncodeToString(address)]
+}
+
+// Scan reads the blocks committed since the last scan, saving the state after
+// every batch. It returns the number of blocks read. If the source's chain no
+// longer contains the last scanned block, the scan starts again from genesis.
+func (s *Scanner) Scan(ctx context.Context, source BlockSource) (int, error) {
+	latest, err := source.LatestHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if latest < s.state.Height {
+		s.reset()
+	}
+
+	scanned := 0
+	for s.state.Height < latest {
+		from := s.state.Height + 1
+		to := from + scanBatchSize - 1
+		if to > latest {
+			to = latest
+		}
+		blocks, err := source.Blocks(ctx, from, to)
+		if err != nil {
+			return scanned, err
+		}
+		if len(blocks) == 0 {
+			return scanned, fmt.Errorf("no blocks returned from height %d", from)
+		}
+
+		for _, block := range blocks {
+			if block.Index != s.state.Height+1 {
+				return scanned, fmt.Errorf("expected block %d, got %d", s.state.Height+1, block.Index)
+			}
+			if block.Index > 0 && hex.EncodeToString(block.PreviousBlockHash) != s.state.BlockHash {
+				// The chain we scanned was replaced; rebuild from genesis
+				s.reset()
+				break
+			}
+			if err := s.applyBlock(block); err != nil {
+				return scanned, fmt.Errorf("block %d: %w", block.Index, err)
+			}
+			scanned++
+		}
+		if err := s.Save(); err != nil {
+			return scanned, err
+		}
+	}
+	return scanned, nil
+}
+
+func (s *Scanner) applyBlock(block *blockchain.Block) error {
+	for _, tx := range block.Transactions {
+		changes, err := tx.BalanceChanges()
+		if err != nil {
+			return err
+		}
+
+		touched := make(map[string]map[string]float64)
+		touch := func(address []byte) map[string]float64 {
+			key := hex.EncodeToString(address)
+			if _, ok := s.state.Addresses[key]; !ok {
+				return nil
+			}
+			if touched[key] == nil {
+				touched[key] = make(map[string]float64)
+			}
+			return touched[key]
+		}
+		touch(tx.Sender)
+		touch(tx.Receiver)
+		for _, change := range changes {
+			if net := touch(change.Address); net != nil {
+				net[change.Asset] += change.Amount
+			}
+		}
+		if len(touched) == 0 {
+			continue
+		}
+
+		hash, err := tx.Hash()
+		if err != nil {
+			return err
+		}
+		asset := tx.Asset
+		if tx.Type == blockchain.TxIssueAsset {
+			if asset, err = blockchain.AssetID(tx); err != nil {
+				return err
+			}
+		}
+		for key, net := range touched {
+			history := s.state.Addresses[key]
+			for asset, amount := range net {
+				history.Balances[asset] += amount
+				if amount == 0 {
+					delete(net, asset)
+				}
+			}
+			history.Activity = append(history.Activity, &Activity{
+				Height:    block.Index,
+				Timestamp: block.Timestamp,
+				TxHash:    hex.EncodeToString(hash),
+				Type:      string(tx.Type),
+				From:      activityAddress(tx.Sender),
+				To:        activityAddress(tx.Receiver),
+				Asset:     asset,
+				Amount:    tx.Amount,
+				Fee:       tx.Fee,
+				Changes:   net,
+				Memo:      tx.Memo,
+			})
+		}
+	}
+
+	s.state.Height = block.Index
+	s.state.BlockHash = hex.EncodeToString(block.CurrentBlockHash)
+	return nil
+}
+
+// activityAddress encodes an address for the history, keeping the names of
+// the system senders readable
+func activityAddress(address []byte) string {
+	if bytes.Equal(address, blockchain.GenesisSender) || bytes.Equal(address, blockchain.ConsensusSender) {
+		return string(address)
+	}
+	return hex.EncodeToString(address)
+}
+
+// Save writes the scanner state to its file
+func (s *Scanner) Save() error {
+	data, err := json.MarshalIndent(&s.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scan state: %w", err)
+	}
+	return writeFileAtomic(s.path, data)
+}
+
+// SortedAssets returns the assets of a balance map, native coin first
+func SortedAssets(balances map[string]float64) []string {
+	assets := make([]string, 0, len(balances))
+	for asset := range balances {
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		if assets[i] == blockchain.NativeAsset || assets[j] == blockchain.NativeAsset {
+			return assets[i] == blockchain.NativeAsset
+		}
+		return assets[i] < assets[j]
+	})
+	return assets
+}
+
+// NodeBlocks reads blocks from a node over gRPC
+func NodeBlocks(client proto.BlockchainServiceClient) BlockSource {
+	return nodeBlocks{client}
+}
+
+type nodeBlocks struct {
+	client proto.BlockchainServiceClient
+}
+
+func (n nodeBlocks) LatestHeight(ctx context.Context) (int, error) {
+	resp, err := n.client.GetLatestBlock(ctx, &proto.GetLatestBlockRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	return int(resp.Height), nil
+}
+
+func (n nodeBlocks) Blocks(ctx context.Context, from, to int) ([]*blockchain.Block, error) {
+	resp, err := n.client.SyncBlocks(ctx, &proto.SyncBlocksRequest{FromHeight: int32(from), ToHeight: int32(to)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blocks %d-%d: %w", from, to, err)
+	}
+	blocks := make([]*blockchain.Block, 0, len(resp.Blocks))
+	for _, pb := range resp.Blocks {
+		block, err := protoconv.BlockFromProto(pb)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", pb.Height, err)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// ChainBlocks reads blocks from a local chain store
+func ChainBlocks(bc *blockchain.Blockchain) BlockSource {
+	return chainBlocks{bc}
+}
+
+type chainBlocks struct {
+	bc *blockchain.Blockchain
+}
+
+func (c chainBlocks) LatestHeight(ctx context.Context) (int, error) {
+	latest := c.bc.GetLatestBlock()
+	if latest == nil {
+		return -1, nil
+	}
+	return latest.Index, nil
+}
+
+func (c chainBlocks) Blocks(ctx context.Context, from, to int) ([]*blockchain.Block, error) {
+	var blocks []*blockchain.Block
+	for height := from; height <= to; height++ {
+		block, err := c.bc.GetBlockByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
@@ -4,25 +4,67 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"fmt"
-	"math/big"
 
 	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
 )
 
-func SignTransaction(tx *blockchain.Transaction, privKey *ecdsa.PrivateKey) error {
-	hash, _ := tx.Hash()
+// SignatureLength is the size of an encoded signature: r and s as 32-byte
+// big-endian integers, r first
+const SignatureLength = blockchain.SignatureLength
+
+var (
+	ErrMissingSignature = blockchain.ErrMissingSignature
+	ErrInvalidSignature = blockchain.ErrInvalidSignature
+	ErrHighS            = blockchain.ErrHighS
+)
+
+// Sign signs a hash and returns a 64-byte low-S signature
+func Sign(hash []byte, privKey *ecdsa.PrivateKey) ([]byte, error) {
 	r, s, err := ecdsa.Sign(rand.Reader, privKey, hash)
 	if err != nil {
-		return fmt.Errorf("sign error: %w", err)
+		return nil, fmt.Errorf("sign error: %w", err)
+	}
+	s = blockchain.NormalizeS(s)
+
+	sig := make([]byte, SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig, nil
+}
+
+// Verify checks a 64-byte signature of a hash
+func Verify(hash, sig []byte, pubKey *ecdsa.PublicKey) error {
+	return blockchain.VerifySignature(hash, sig, pubKey)
+}
+
+// SignTransaction signs a transaction with an ECDSA P-256 key
+func SignTransaction(tx *blockchain.Transaction, privKey *ecdsa.PrivateKey) error {
+	return SignTransactionWith(tx, NewP256Signer(privKey))
+}
+
+// SignTransactionWith embeds the signer's scheme and public key in the
+// transaction and signs it
+func SignTransactionWith(tx *blockchain.Transaction, signer Signer) error {
+	tx.Scheme = signer.Scheme()
+	tx.PublicKey = signer.PublicKey()
+	hash, err := tx.Hash()
+	if err != nil {
+		return err
+	}
+	sig, err := signer.Sign(hash)
+	if err != nil {
+		return err
 	}
 
-	tx.Signature = append(r.Bytes(), s.Bytes()...)
+	tx.Signature = sig
 	return nil
 }
 
-func VerifyTransaction(tx *blockchain.Transaction, pubKey *ecdsa.PublicKey) bool {
-	hash, _ := tx.Hash()
-	r := new(big.Int).SetBytes(tx.Signature[:len(tx.Signature)/2])
-	s := new(big.Int).SetBytes(tx.Signature[len(tx.Signature)/2:])
-	return ecdsa.Verify(pubKey, hash, r, s)
+// VerifyTransaction checks the transaction signature against a public key
+func VerifyTransaction(tx *blockchain.Transaction, pubKey *ecdsa.PublicKey) error {
+	hash, err := tx.Hash()
+	if err != nil {
+		return err
+	}
+	return Verify(hash, tx.Signature, pubKey)
 }
@@ -0,0 +1,138 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// Signer signs hashes with one private key. Its Verifier methods describe the
+// matching public key.
+type Signer interface {
+	blockchain.Verifier
+	Sign(hash []byte) ([]byte, error)
+}
+
+// Schemes lists the signature schemes wallets can create keys for
+var Schemes = []blockchain.SignatureScheme{blockchain.SchemeP256, blockchain.SchemeEd25519}
+
+// ParseScheme turns a scheme name from the command line into a scheme.
+// "p256" names the default ECDSA P-256 scheme.
+func ParseScheme(name string) (blockchain.SignatureScheme, error) {
+	switch name {
+	case "", "p256", "ecdsa":
+		return blockchain.SchemeP256, nil
+	case string(blockchain.SchemeEd25519):
+		return blockchain.SchemeEd25519, nil
+	default:
+		return "", fmt.Errorf("unknown signature scheme %q (want p256 or ed25519)", name)
+	}
+}
+
+// SchemeName returns the command-line name of a scheme
+func SchemeName(scheme blockchain.SignatureScheme) string {
+	if scheme == blockchain.SchemeP256 {
+		return "p256"
+	}
+	return string(scheme)
+}
+
+// GenerateSigner creates a new random key of the given scheme
+func GenerateSigner(scheme blockchain.SignatureScheme) (Signer, error) {
+	switch scheme {
+	case blockchain.SchemeP256:
+		priv, err := GenerateKeyPair()
+		if err != nil {
+			return nil, err
+		}
+		return NewP256Signer(priv), nil
+	case blockchain.SchemeEd25519:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return NewEd25519Signer(priv), nil
+	default:
+		return nil, fmt.Errorf("unknown signature scheme %q", scheme)
+	}
+}
+
+// P256Signer signs with an ECDSA P-256 key
+type P256Signer struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewP256Signer wraps an ECDSA P-256 private key
+func NewP256Signer(priv *ecdsa.PrivateKey) *P256Signer {
+	return &P256Signer{key: priv}
+}
+
+func (s *P256Signer) Scheme() blockchain.SignatureScheme { return blockchain.SchemeP256 }
+func (s *P256Signer) PublicKey() []byte                  { return blockchain.MarshalPublicKey(&s.key.PublicKey) }
+func (s *P256Signer) Address() []byte                    { return PublicKeyToAddress(&s.key.PublicKey) }
+func (s *P256Signer) Sign(hash []byte) ([]byte, error)   { return Sign(hash, s.key) }
+
+func (s *P256Signer) Verify(hash, sig []byte) error {
+	return Verify(hash, sig, &s.key.PublicKey)
+}
+
+// PrivateKey returns the wrapped ECDSA key
+func (s *P256Signer) PrivateKey() *ecdsa.PrivateKey {
+	return s.key
+}
+
+// Ed25519Signer signs with an Ed25519 key
+type Ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+// NewEd25519Signer wraps an Ed25519 private key
+func NewEd25519Signer(priv ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{key: priv}
+}
+
+func (s *Ed25519Signer) Scheme() blockchain.SignatureScheme { return blockchain.SchemeEd25519 }
+func (s *Ed25519Signer) PublicKey() []byte                  { return s.key.Public().(ed25519.PublicKey) }
+
+func (s *Ed25519Signer) Address() []byte {
+	return blockchain.SchemeAddress(blockchain.SchemeEd25519, s.PublicKey())
+}
+
+func (s *Ed25519Signer) Sign(hash []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, hash), nil
+}
+
+func (s *Ed25519Signer) Verify(hash, sig []byte) error {
+	verifier, err := blockchain.ParseVerifier(blockchain.SchemeEd25519, s.PublicKey())
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(hash, sig)
+}
+
+// rotatedSigner signs for an account whose key was rotated: its address is
+// the account's, not the one derived from the key
+type rotatedSigner struct {
+	Signer
+	account Address
+}
+
+// RotatedSigner returns a signer that signs for account with the key of
+// signer, for use after account was rotated to that key
+func RotatedSigner(signer Signer, account []byte) Signer {
+	if rotated, ok := signer.(*rotatedSigner); ok {
+		signer = rotated.Signer
+	}
+	if bytes.Equal(signer.Address(), account) {
+		return signer
+	}
+	return &rotatedSigner{Signer: signer, account: account}
+}
+
+func (s *rotatedSigner) Address() []byte {
+	return s.account
+}
@@ -0,0 +1,163 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/nguyentrinhquy1411/blockchain-go/pkg/blockchain"
+)
+
+// TxFileVersion is the current version of the transaction file format
+const TxFileVersion = 1
+
+// TxFile is the portable format used to move a transaction between an online
+// machine that builds and broadcasts it and an offline one that signs it, e.g.
+//
+//	{
+//	  "version": 1,
+//	  "chain_id": "blockchain-go",
+//	  "nonce": 3,
+//	  "from": "bgo1...",
+//	  "to": "bgo1...",
+//	  "amount": 25,
+//	  "fee": 0.01,
+//	  "timestamp": 1760000000,
+//	  "scheme": "p256",
+//	  "public_key": "<hex>",
+//	  "signature": "<hex>"
+//	}
+//
+// Addresses are Bech32 (legacy hex is accepted), binary fields are hex and
+// "type", "asset" and "data" are omitted for plain native transfers, and
+// "memo" (encrypted to the receiver) when there is none.
+// "scheme", "public_key" and "signature" are only present once signed.
+type TxFile struct {
+	Version   int     `json:"version"`
+	ChainID   string  `json:"chain_id"`
+	Nonce     uint64  `json:"nonce"`
+	Type      string  `json:"type,omitempty"`
+	From      string  `json:"from"`
+	To        string  `json:"to,omitempty"`
+	Amount    float64 `json:"amount"`
+	Asset     string  `json:"asset,omitempty"`
+	Data      string  `json:"data,omitempty"`
+	Memo      string  `json:"memo,omitempty"` // Encrypted memo
+	Fee       float64 `json:"fee"`
+	Timestamp int64   `json:"timestamp"`
+
+	Scheme    string `json:"scheme,omitempty"`
+	PublicKey string `json:"public_key,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// NewTxFile encodes a transaction, signed or not, as a transaction file
+func NewTxFile(tx *blockchain.Transaction) *TxFile {
+	f := &TxFile{
+		Version:   TxFileVersion,
+		ChainID:   tx.ChainID,
+		Nonce:     tx.Nonce,
+		Type:      string(tx.Type),
+		From:      FormatAddress(tx.Sender),
+		Amount:    tx.Amount,
+		Asset:     tx.Asset,
+		Fee:       tx.Fee,
+		Timestamp: tx.Timestamp,
+	}
+	if len(tx.Receiver) > 0 {
+		f.To = FormatAddress(tx.Receiver)
+	}
+	if len(tx.Data) > 0 {
+		f.Data = hex.EncodeToString(tx.Data)
+	}
+	if len(tx.Memo) > 0 {
+		f.Memo = hex.EncodeToString(tx.Memo)
+	}
+	if len(tx.Signature) > 0 {
+		f.Scheme = SchemeName(tx.Scheme)
+		f.PublicKey = hex.EncodeToString(tx.PublicKey)
+		f.Signature = hex.EncodeToString(tx.Signature)
+	}
+	return f
+}
+
+// Signed reports whether the file carries a signature
+func (f *TxFile) Signed() bool {
+	return f.Signature != ""
+}
+
+// Transaction decodes the transaction held by the file
+func (f *TxFile) Transaction() (*blockchain.Transaction, error) {
+	if f.Version != TxFileVersion {
+		return nil, fmt.Errorf("unsupported transaction file version %d", f.Version)
+	}
+
+	sender, err := ParseAddress(f.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from: %w", err)
+	}
+	tx := &blockchain.Transaction{
+		Sender:    sender,
+		Amount:    f.Amount,
+		Timestamp: f.Timestamp,
+		Type:      blockchain.TxType(f.Type),
+		Asset:     f.Asset,
+		Fee:       f.Fee,
+		Nonce:     f.Nonce,
+		ChainID:   f.ChainID,
+	}
+	if f.To != "" {
+		if tx.Receiver, err = ParseAddress(f.To); err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if f.Data != "" {
+		if tx.Data, err = hex.DecodeString(f.Data); err != nil {
+			return nil, fmt.Errorf("invalid data: %w", err)
+		}
+	}
+	if f.Memo != "" {
+		if tx.Memo, err = hex.DecodeString(f.Memo); err != nil {
+			return nil, fmt.Errorf("invalid memo: %w", err)
+		}
+	}
+
+	if f.Signed() {
+		if tx.Scheme, err = ParseScheme(f.Scheme); err != nil {
+			return nil, err
+		}
+		if tx.PublicKey, err = hex.DecodeString(f.PublicKey); err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+		if tx.Signature, err = hex.DecodeString(f.Signature); err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+	}
+	return tx, nil
+}
+
+// SaveTxFile writes a transaction file to path
+func SaveTxFile(path string, f *TxFile) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction file: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write transaction file %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadTxFile reads a transaction file from path
+func LoadTxFile(path string) (*TxFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction file %s: %w", path, err)
+	}
+	var f TxFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction file %s: %w", path, err)
+	}
+	return &f, nil
+}
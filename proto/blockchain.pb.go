@@ -23,12 +23,25 @@ const (
 
 // Messages cho giao dịch
 type Transaction struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sender        string                 `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
-	Receiver      string                 `protobuf:"bytes,2,opt,name=receiver,proto3" json:"receiver,omitempty"`
-	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Signature     []byte                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sender and receiver are raw hex rather than Bech32: nodes sync blocks
+	// with this message, system senders such as "consensus" are not
+	// addresses, and hex does not depend on the network. Clients convert them
+	// for display.
+	Sender        string  `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`     // Hex address; SendTransaction also takes Bech32 or a name
+	Receiver      string  `protobuf:"bytes,2,opt,name=receiver,proto3" json:"receiver,omitempty"` // Hex address; SendTransaction also takes Bech32 or a name
+	Amount        float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Timestamp     int64   `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Signature     []byte  `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	Type          string  `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`                             // Empty for a plain transfer
+	Asset         string  `protobuf:"bytes,7,opt,name=asset,proto3" json:"asset,omitempty"`                           // Asset ID, empty for the native coin
+	Data          []byte  `protobuf:"bytes,8,opt,name=data,proto3" json:"data,omitempty"`                             // Type-specific JSON payload
+	Fee           float64 `protobuf:"fixed64,9,opt,name=fee,proto3" json:"fee,omitempty"`                             // Native coin paid to the block proposer
+	PublicKey     []byte  `protobuf:"bytes,10,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"` // Sender public key (compressed P-256 or Ed25519)
+	Scheme        string  `protobuf:"bytes,11,opt,name=scheme,proto3" json:"scheme,omitempty"`                        // Signature scheme, empty for ECDSA P-256
+	Nonce         uint64  `protobuf:"varint,12,opt,name=nonce,proto3" json:"nonce,omitempty"`                         // Sender's next nonce
+	ChainId       string  `protobuf:"bytes,13,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`       // Chain the transaction is valid on
+	Memo          []byte  `protobuf:"bytes,14,opt,name=memo,proto3" json:"memo,omitempty"`                            // Memo encrypted to the receiver's key, if any
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -98,6 +111,69 @@ func (x *Transaction) GetSignature() []byte {
 	return nil
 }
 
+func (x *Transaction) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Transaction) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Transaction) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Transaction) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Transaction) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *Transaction) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *Transaction) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Transaction) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *Transaction) GetMemo() []byte {
+	if x != nil {
+		return x.Memo
+	}
+	return nil
+}
+
 // Messages cho block
 type Block struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -188,6 +264,7 @@ type ProposeBlockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
 	ProposerId    string                 `protobuf:"bytes,2,opt,name=proposer_id,json=proposerId,proto3" json:"proposer_id,omitempty"`
+	Signature     *NodeSignature         `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"` // Proposer's signature, optional
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -236,6 +313,13 @@ func (x *ProposeBlockRequest) GetProposerId() string {
 	return ""
 }
 
+func (x *ProposeBlockRequest) GetSignature() *NodeSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 type ProposeBlockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
@@ -294,6 +378,7 @@ type VoteRequest struct {
 	BlockHash     string                 `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
 	VoterId       string                 `protobuf:"bytes,2,opt,name=voter_id,json=voterId,proto3" json:"voter_id,omitempty"`
 	Approve       bool                   `protobuf:"varint,3,opt,name=approve,proto3" json:"approve,omitempty"`
+	Signature     *NodeSignature         `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"` // Voter's signature, optional
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -349,6 +434,13 @@ func (x *VoteRequest) GetApprove() bool {
 	return false
 }
 
+func (x *VoteRequest) GetSignature() *NodeSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 type VoteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -674,6 +766,7 @@ type SendTransactionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	TxHash        string                 `protobuf:"bytes,3,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"` // Hex hash to look the transaction up with GetTransaction
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -722,6 +815,13 @@ func (x *SendTransactionResponse) GetMessage() string {
 	return ""
 }
 
+func (x *SendTransactionResponse) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
 // Request/Response cho SyncBlocks
 type SyncBlocksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -819,72 +919,2300 @@ func (x *SyncBlocksResponse) GetBlocks() []*Block {
 	return nil
 }
 
-var File_proto_blockchain_proto protoreflect.FileDescriptor
+// Request/Response cho NotifyCommittedBlock
+type NotifyCommittedBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_blockchain_proto_rawDesc = "" +
-	"\n" +
-	"\x16proto/blockchain.proto\x12\n" +
-	"blockchain\"\x95\x01\n" +
-	"\vTransaction\x12\x16\n" +
-	"\x06sender\x18\x01 \x01(\tR\x06sender\x12\x1a\n" +
-	"\breceiver\x18\x02 \x01(\tR\breceiver\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x1c\n" +
-	"\tsignature\x18\x05 \x01(\fR\tsignature\"\xd4\x01\n" +
-	"\x05Block\x12\x16\n" +
-	"\x06height\x18\x01 \x01(\x05R\x06height\x12#\n" +
-	"\rprevious_hash\x18\x02 \x01(\tR\fpreviousHash\x12\x1f\n" +
-	"\vmerkle_root\x18\x03 \x01(\tR\n" +
-	"merkleRoot\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12;\n" +
-	"\ftransactions\x18\x05 \x03(\v2\x17.blockchain.TransactionR\ftransactions\x12\x12\n" +
-	"\x04hash\x18\x06 \x01(\tR\x04hash\"_\n" +
-	"\x13ProposeBlockRequest\x12'\n" +
-	"\x05block\x18\x01 \x01(\v2\x11.blockchain.BlockR\x05block\x12\x1f\n" +
-	"\vproposer_id\x18\x02 \x01(\tR\n" +
-	"proposerId\"L\n" +
-	"\x14ProposeBlockResponse\x12\x1a\n" +
-	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"a\n" +
-	"\vVoteRequest\x12\x1d\n" +
-	"\n" +
-	"block_hash\x18\x01 \x01(\tR\tblockHash\x12\x19\n" +
-	"\bvoter_id\x18\x02 \x01(\tR\avoterId\x12\x18\n" +
-	"\aapprove\x18\x03 \x01(\bR\aapprove\"B\n" +
-	"\fVoteResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"O\n" +
-	"\x0fGetBlockRequest\x12\x18\n" +
-	"\x06height\x18\x01 \x01(\x05H\x00R\x06height\x12\x14\n" +
-	"\x04hash\x18\x02 \x01(\tH\x00R\x04hashB\f\n" +
-	"\n" +
-	"identifier\"Q\n" +
-	"\x10GetBlockResponse\x12'\n" +
-	"\x05block\x18\x01 \x01(\v2\x11.blockchain.BlockR\x05block\x12\x14\n" +
-	"\x05found\x18\x02 \x01(\bR\x05found\"\x17\n" +
-	"\x15GetLatestBlockRequest\"Y\n" +
-	"\x16GetLatestBlockResponse\x12'\n" +
-	"\x05block\x18\x01 \x01(\v2\x11.blockchain.BlockR\x05block\x12\x16\n" +
-	"\x06height\x18\x02 \x01(\x05R\x06height\"S\n" +
-	"\x16SendTransactionRequest\x129\n" +
-	"\vtransaction\x18\x01 \x01(\v2\x17.blockchain.TransactionR\vtransaction\"O\n" +
-	"\x17SendTransactionResponse\x12\x1a\n" +
-	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"Q\n" +
-	"\x11SyncBlocksRequest\x12\x1f\n" +
-	"\vfrom_height\x18\x01 \x01(\x05R\n" +
-	"fromHeight\x12\x1b\n" +
-	"\tto_height\x18\x02 \x01(\x05R\btoHeight\"?\n" +
-	"\x12SyncBlocksResponse\x12)\n" +
-	"\x06blocks\x18\x01 \x03(\v2\x11.blockchain.BlockR\x06blocks2\xea\x03\n" +
-	"\x11BlockchainService\x12Q\n" +
-	"\fProposeBlock\x12\x1f.blockchain.ProposeBlockRequest\x1a .blockchain.ProposeBlockResponse\x129\n" +
-	"\x04Vote\x12\x17.blockchain.VoteRequest\x1a\x18.blockchain.VoteResponse\x12E\n" +
-	"\bGetBlock\x12\x1b.blockchain.GetBlockRequest\x1a\x1c.blockchain.GetBlockResponse\x12W\n" +
-	"\x0eGetLatestBlock\x12!.blockchain.GetLatestBlockRequest\x1a\".blockchain.GetLatestBlockResponse\x12Z\n" +
-	"\x0fSendTransaction\x12\".blockchain.SendTransactionRequest\x1a#.blockchain.SendTransactionResponse\x12K\n" +
+func (x *NotifyCommittedBlockRequest) Reset() {
+	*x = NotifyCommittedBlockRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyCommittedBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyCommittedBlockRequest) ProtoMessage() {}
+
+func (x *NotifyCommittedBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyCommittedBlockRequest.ProtoReflect.Descriptor instead.
+func (*NotifyCommittedBlockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NotifyCommittedBlockRequest) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+type NotifyCommittedBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyCommittedBlockResponse) Reset() {
+	*x = NotifyCommittedBlockResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyCommittedBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyCommittedBlockResponse) ProtoMessage() {}
+
+func (x *NotifyCommittedBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyCommittedBlockResponse.ProtoReflect.Descriptor instead.
+func (*NotifyCommittedBlockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NotifyCommittedBlockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *NotifyCommittedBlockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Messages cho assets
+type Asset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals      int32                  `protobuf:"varint,3,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Supply        float64                `protobuf:"fixed64,4,opt,name=supply,proto3" json:"supply,omitempty"`
+	Issuer        string                 `protobuf:"bytes,5,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	Height        int32                  `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	mi := &file_proto_blockchain_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Asset) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Asset) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Asset) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Asset) GetSupply() float64 {
+	if x != nil {
+		return x.Supply
+	}
+	return 0
+}
+
+func (x *Asset) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *Asset) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// Request/Response cho ListAssets
+type ListAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsRequest) Reset() {
+	*x = ListAssetsRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsRequest) ProtoMessage() {}
+
+func (x *ListAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{17}
+}
+
+type ListAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsResponse) Reset() {
+	*x = ListAssetsResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsResponse) ProtoMessage() {}
+
+func (x *ListAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListAssetsResponse) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+// Request/Response cho GetBalance
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Asset         string                 `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"` // Empty to list every asset held by the address
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetBalanceRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetBalanceRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+type AssetBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Balance       float64                `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetBalance) Reset() {
+	*x = AssetBalance{}
+	mi := &file_proto_blockchain_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetBalance) ProtoMessage() {}
+
+func (x *AssetBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetBalance.ProtoReflect.Descriptor instead.
+func (*AssetBalance) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AssetBalance) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *AssetBalance) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AssetBalance) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type GetBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balances      []*AssetBalance        `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetBalanceResponse) GetBalances() []*AssetBalance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+// Request/Response cho ResolveName
+type ResolveNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveNameRequest) Reset() {
+	*x = ResolveNameRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveNameRequest) ProtoMessage() {}
+
+func (x *ResolveNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveNameRequest.ProtoReflect.Descriptor instead.
+func (*ResolveNameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ResolveNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ResolveNameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`       // Normalized name
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"` // Owner address (Bech32)
+	Expiry        int32                  `protobuf:"varint,4,opt,name=expiry,proto3" json:"expiry,omitempty"`  // Last block height at which the name is valid
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveNameResponse) Reset() {
+	*x = ResolveNameResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveNameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveNameResponse) ProtoMessage() {}
+
+func (x *ResolveNameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveNameResponse.ProtoReflect.Descriptor instead.
+func (*ResolveNameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ResolveNameResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *ResolveNameResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResolveNameResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ResolveNameResponse) GetExpiry() int32 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+// Request/Response cho GetAnchor
+type GetAnchorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"` // SHA-256 of the document (hex)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnchorRequest) Reset() {
+	*x = GetAnchorRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnchorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnchorRequest) ProtoMessage() {}
+
+func (x *GetAnchorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnchorRequest.ProtoReflect.Descriptor instead.
+func (*GetAnchorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetAnchorRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type MerkleProofStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Left          bool                   `protobuf:"varint,2,opt,name=left,proto3" json:"left,omitempty"` // Sibling is the left operand of the pair
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MerkleProofStep) Reset() {
+	*x = MerkleProofStep{}
+	mi := &file_proto_blockchain_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MerkleProofStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerkleProofStep) ProtoMessage() {}
+
+func (x *MerkleProofStep) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerkleProofStep.ProtoReflect.Descriptor instead.
+func (*MerkleProofStep) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MerkleProofStep) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *MerkleProofStep) GetLeft() bool {
+	if x != nil {
+		return x.Left
+	}
+	return false
+}
+
+type GetAnchorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	BlockHash     string                 `protobuf:"bytes,6,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	MerkleRoot    string                 `protobuf:"bytes,7,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root,omitempty"`
+	TxIndex       int32                  `protobuf:"varint,8,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	Transaction   *Transaction           `protobuf:"bytes,9,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Proof         []*MerkleProofStep     `protobuf:"bytes,10,rep,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnchorResponse) Reset() {
+	*x = GetAnchorResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnchorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnchorResponse) ProtoMessage() {}
+
+func (x *GetAnchorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnchorResponse.ProtoReflect.Descriptor instead.
+func (*GetAnchorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetAnchorResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetAnchorResponse) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *GetAnchorResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetAnchorResponse) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetAnchorResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *GetAnchorResponse) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *GetAnchorResponse) GetMerkleRoot() string {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return ""
+}
+
+func (x *GetAnchorResponse) GetTxIndex() int32 {
+	if x != nil {
+		return x.TxIndex
+	}
+	return 0
+}
+
+func (x *GetAnchorResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *GetAnchorResponse) GetProof() []*MerkleProofStep {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// Request/Response cho GetAllowances
+type GetAllowancesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Spender       string                 `protobuf:"bytes,2,opt,name=spender,proto3" json:"spender,omitempty"` // Optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllowancesRequest) Reset() {
+	*x = GetAllowancesRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllowancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllowancesRequest) ProtoMessage() {}
+
+func (x *GetAllowancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllowancesRequest.ProtoReflect.Descriptor instead.
+func (*GetAllowancesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetAllowancesRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *GetAllowancesRequest) GetSpender() string {
+	if x != nil {
+		return x.Spender
+	}
+	return ""
+}
+
+type Allowance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Spender       string                 `protobuf:"bytes,2,opt,name=spender,proto3" json:"spender,omitempty"`
+	Asset         string                 `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Allowance) Reset() {
+	*x = Allowance{}
+	mi := &file_proto_blockchain_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Allowance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Allowance) ProtoMessage() {}
+
+func (x *Allowance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Allowance.ProtoReflect.Descriptor instead.
+func (*Allowance) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Allowance) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Allowance) GetSpender() string {
+	if x != nil {
+		return x.Spender
+	}
+	return ""
+}
+
+func (x *Allowance) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Allowance) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type GetAllowancesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowances    []*Allowance           `protobuf:"bytes,1,rep,name=allowances,proto3" json:"allowances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllowancesResponse) Reset() {
+	*x = GetAllowancesResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllowancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllowancesResponse) ProtoMessage() {}
+
+func (x *GetAllowancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllowancesResponse.ProtoReflect.Descriptor instead.
+func (*GetAllowancesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetAllowancesResponse) GetAllowances() []*Allowance {
+	if x != nil {
+		return x.Allowances
+	}
+	return nil
+}
+
+// Request/Response cho GetVesting
+type GetVestingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVestingRequest) Reset() {
+	*x = GetVestingRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVestingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVestingRequest) ProtoMessage() {}
+
+func (x *GetVestingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVestingRequest.ProtoReflect.Descriptor instead.
+func (*GetVestingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetVestingRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type VestingSchedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int64                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	Cliff         int64                  `protobuf:"varint,2,opt,name=cliff,proto3" json:"cliff,omitempty"`
+	End           int64                  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	Total         float64                `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`
+	Vested        float64                `protobuf:"fixed64,5,opt,name=vested,proto3" json:"vested,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VestingSchedule) Reset() {
+	*x = VestingSchedule{}
+	mi := &file_proto_blockchain_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VestingSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VestingSchedule) ProtoMessage() {}
+
+func (x *VestingSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VestingSchedule.ProtoReflect.Descriptor instead.
+func (*VestingSchedule) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VestingSchedule) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *VestingSchedule) GetCliff() int64 {
+	if x != nil {
+		return x.Cliff
+	}
+	return 0
+}
+
+func (x *VestingSchedule) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *VestingSchedule) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *VestingSchedule) GetVested() float64 {
+	if x != nil {
+		return x.Vested
+	}
+	return 0
+}
+
+type GetVestingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Height        int64                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Balance       float64                `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	Vested        float64                `protobuf:"fixed64,4,opt,name=vested,proto3" json:"vested,omitempty"`
+	Locked        float64                `protobuf:"fixed64,5,opt,name=locked,proto3" json:"locked,omitempty"`
+	Spendable     float64                `protobuf:"fixed64,6,opt,name=spendable,proto3" json:"spendable,omitempty"`
+	Schedules     []*VestingSchedule     `protobuf:"bytes,7,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVestingResponse) Reset() {
+	*x = GetVestingResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVestingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVestingResponse) ProtoMessage() {}
+
+func (x *GetVestingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVestingResponse.ProtoReflect.Descriptor instead.
+func (*GetVestingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetVestingResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetVestingResponse) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetVestingResponse) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *GetVestingResponse) GetVested() float64 {
+	if x != nil {
+		return x.Vested
+	}
+	return 0
+}
+
+func (x *GetVestingResponse) GetLocked() float64 {
+	if x != nil {
+		return x.Locked
+	}
+	return 0
+}
+
+func (x *GetVestingResponse) GetSpendable() float64 {
+	if x != nil {
+		return x.Spendable
+	}
+	return 0
+}
+
+func (x *GetVestingResponse) GetSchedules() []*VestingSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+// Request/Response cho GetAccount: what a client needs to build a transaction
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetAccountRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type GetAccountResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	ChainId string                 `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Nonce   uint64                 `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`                  // Next nonce, counting transactions still pending
+	Balance float64                `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`             // Native coin balance
+	MinFee  float64                `protobuf:"fixed64,5,opt,name=min_fee,json=minFee,proto3" json:"min_fee,omitempty"` // Lowest fee this node accepts
+	Height  int64                  `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	// Key that currently signs for the account, empty until it sent a
+	// transaction; rotated_at is the height of its last key rotation, if any
+	KeyScheme     string `protobuf:"bytes,7,opt,name=key_scheme,json=keyScheme,proto3" json:"key_scheme,omitempty"`
+	PublicKey     []byte `protobuf:"bytes,8,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	RotatedAt     int64  `protobuf:"varint,9,opt,name=rotated_at,json=rotatedAt,proto3" json:"rotated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountResponse) Reset() {
+	*x = GetAccountResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountResponse) ProtoMessage() {}
+
+func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetAccountResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *GetAccountResponse) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *GetAccountResponse) GetMinFee() float64 {
+	if x != nil {
+		return x.MinFee
+	}
+	return 0
+}
+
+func (x *GetAccountResponse) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetAccountResponse) GetKeyScheme() string {
+	if x != nil {
+		return x.KeyScheme
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *GetAccountResponse) GetRotatedAt() int64 {
+	if x != nil {
+		return x.RotatedAt
+	}
+	return 0
+}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"` // Hex transaction hash
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetTransactionRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type GetTransactionResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Found       bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Transaction *Transaction           `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Still waiting in the leader's pool; the block fields below are unset
+	Pending        bool   `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	BlockHeight    int64  `protobuf:"varint,4,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockHash      string `protobuf:"bytes,5,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockTimestamp int64  `protobuf:"varint,6,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	Position       int32  `protobuf:"varint,7,opt,name=position,proto3" json:"position,omitempty"`           // Index in the block's transactions
+	Confirmations  int64  `protobuf:"varint,8,opt,name=confirmations,proto3" json:"confirmations,omitempty"` // Blocks from the transaction's block to the tip, inclusive
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetTransactionResponse) Reset() {
+	*x = GetTransactionResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResponse) ProtoMessage() {}
+
+func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetTransactionResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetTransactionResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *GetTransactionResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *GetTransactionResponse) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *GetTransactionResponse) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *GetTransactionResponse) GetBlockTimestamp() int64 {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return 0
+}
+
+func (x *GetTransactionResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *GetTransactionResponse) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+type GetAddressHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`     // Address or registered name
+	Direction     string                 `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"` // "sent", "received", or empty for both
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`        // Page size, default 20, at most 100
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`       // next_cursor of the previous page
+	OldestFirst   bool                   `protobuf:"varint,5,opt,name=oldest_first,json=oldestFirst,proto3" json:"oldest_first,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressHistoryRequest) Reset() {
+	*x = GetAddressHistoryRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressHistoryRequest) ProtoMessage() {}
+
+func (x *GetAddressHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAddressHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetAddressHistoryRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetAddressHistoryRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *GetAddressHistoryRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAddressHistoryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetAddressHistoryRequest) GetOldestFirst() bool {
+	if x != nil {
+		return x.OldestFirst
+	}
+	return false
+}
+
+type AddressHistoryEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TxHash         string                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	BlockHeight    int64                  `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Position       int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	BlockTimestamp int64                  `protobuf:"varint,4,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	Sent           bool                   `protobuf:"varint,5,opt,name=sent,proto3" json:"sent,omitempty"`
+	Received       bool                   `protobuf:"varint,6,opt,name=received,proto3" json:"received,omitempty"`
+	Transaction    *Transaction           `protobuf:"bytes,7,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddressHistoryEntry) Reset() {
+	*x = AddressHistoryEntry{}
+	mi := &file_proto_blockchain_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressHistoryEntry) ProtoMessage() {}
+
+func (x *AddressHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressHistoryEntry.ProtoReflect.Descriptor instead.
+func (*AddressHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AddressHistoryEntry) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *AddressHistoryEntry) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *AddressHistoryEntry) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *AddressHistoryEntry) GetBlockTimestamp() int64 {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return 0
+}
+
+func (x *AddressHistoryEntry) GetSent() bool {
+	if x != nil {
+		return x.Sent
+	}
+	return false
+}
+
+func (x *AddressHistoryEntry) GetReceived() bool {
+	if x != nil {
+		return x.Received
+	}
+	return false
+}
+
+func (x *AddressHistoryEntry) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type GetAddressHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Entries       []*AddressHistoryEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty after the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressHistoryResponse) Reset() {
+	*x = GetAddressHistoryResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressHistoryResponse) ProtoMessage() {}
+
+func (x *GetAddressHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetAddressHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetAddressHistoryResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetAddressHistoryResponse) GetEntries() []*AddressHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetAddressHistoryResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// A signature together with the key and scheme needed to check it
+type NodeSignature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scheme        string                 `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeSignature) Reset() {
+	*x = NodeSignature{}
+	mi := &file_proto_blockchain_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeSignature) ProtoMessage() {}
+
+func (x *NodeSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeSignature.ProtoReflect.Descriptor instead.
+func (*NodeSignature) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *NodeSignature) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *NodeSignature) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *NodeSignature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// Messages cho SignerService
+type SignerKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // Bech32
+	Scheme        string                 `protobuf:"bytes,3,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignerKey) Reset() {
+	*x = SignerKey{}
+	mi := &file_proto_blockchain_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignerKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignerKey) ProtoMessage() {}
+
+func (x *SignerKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignerKey.ProtoReflect.Descriptor instead.
+func (*SignerKey) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SignerKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SignerKey) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SignerKey) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *SignerKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+type ListKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKeysRequest) Reset() {
+	*x = ListKeysRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKeysRequest) ProtoMessage() {}
+
+func (x *ListKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListKeysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{42}
+}
+
+type ListKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*SignerKey           `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKeysResponse) Reset() {
+	*x = ListKeysResponse{}
+	mi := &file_proto_blockchain_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKeysResponse) ProtoMessage() {}
+
+func (x *ListKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListKeysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListKeysResponse) GetKeys() []*SignerKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type SignTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`                 // Key name
+	Transaction   *Transaction           `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"` // Unsigned; the signer hashes it itself
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignTransactionRequest) Reset() {
+	*x = SignTransactionRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignTransactionRequest) ProtoMessage() {}
+
+func (x *SignTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTransactionRequest.ProtoReflect.Descriptor instead.
+func (*SignTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SignTransactionRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SignTransactionRequest) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type SignProposalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Height        int64                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash     string                 `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignProposalRequest) Reset() {
+	*x = SignProposalRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignProposalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignProposalRequest) ProtoMessage() {}
+
+func (x *SignProposalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignProposalRequest.ProtoReflect.Descriptor instead.
+func (*SignProposalRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SignProposalRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SignProposalRequest) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *SignProposalRequest) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+type SignVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	BlockHash     string                 `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	VoterId       string                 `protobuf:"bytes,3,opt,name=voter_id,json=voterId,proto3" json:"voter_id,omitempty"`
+	Approve       bool                   `protobuf:"varint,4,opt,name=approve,proto3" json:"approve,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignVoteRequest) Reset() {
+	*x = SignVoteRequest{}
+	mi := &file_proto_blockchain_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignVoteRequest) ProtoMessage() {}
+
+func (x *SignVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_blockchain_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignVoteRequest.ProtoReflect.Descriptor instead.
+func (*SignVoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_blockchain_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SignVoteRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SignVoteRequest) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *SignVoteRequest) GetVoterId() string {
+	if x != nil {
+		return x.VoterId
+	}
+	return ""
+}
+
+func (x *SignVoteRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+var File_proto_blockchain_proto protoreflect.FileDescriptor
+
+const file_proto_blockchain_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/blockchain.proto\x12\n" +
+	"blockchain\"\xe1\x02\n" +
+	"\vTransaction\x12\x16\n" +
+	"\x06sender\x18\x01 \x01(\tR\x06sender\x12\x1a\n" +
+	"\breceiver\x18\x02 \x01(\tR\breceiver\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\fR\tsignature\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12\x14\n" +
+	"\x05asset\x18\a \x01(\tR\x05asset\x12\x12\n" +
+	"\x04data\x18\b \x01(\fR\x04data\x12\x10\n" +
+	"\x03fee\x18\t \x01(\x01R\x03fee\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\n" +
+	" \x01(\fR\tpublicKey\x12\x16\n" +
+	"\x06scheme\x18\v \x01(\tR\x06scheme\x12\x14\n" +
+	"\x05nonce\x18\f \x01(\x04R\x05nonce\x12\x19\n" +
+	"\bchain_id\x18\r \x01(\tR\achainId\x12\x12\n" +
+	"\x04memo\x18\x0e \x01(\fR\x04memo\"\xd4\x01\n" +
+	"\x05Block\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x05R\x06height\x12#\n" +
+	"\rprevious_hash\x18\x02 \x01(\tR\fpreviousHash\x12\x1f\n" +
+	"\vmerkle_root\x18\x03 \x01(\tR\n" +
+	"merkleRoot\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12;\n" +
+	"\ftransactions\x18\x05 \x03(\v2\x17.blockchain.TransactionR\ftransactions\x12\x12\n" +
+	"\x04hash\x18\x06 \x01(\tR\x04hash\"\x98\x01\n" +
+	"\x13ProposeBlockRequest\x12'\n" +
+	"\x05block\x18\x01 \x01(\v2\x11.blockchain.BlockR\x05block\x12\x1f\n" +
+	"\vproposer_id\x18\x02 \x01(\tR\n" +
+	"proposerId\x127\n" +
+	"\tsignature\x18\x03 \x01(\v2\x19.blockchain.NodeSignatureR\tsignature\"L\n" +
+	"\x14ProposeBlockResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9a\x01\n" +
+	"\vVoteRequest\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x01 \x01(\tR\tblockHash\x12\x19\n" +
+	"\bvoter_id\x18\x02 \x01(\tR\avoterId\x12\x18\n" +
+	"\aapprove\x18\x03 \x01(\bR\aapprove\x127\n" +
+	"\tsignature\x18\x04 \x01(\v2\x19.blockchain.NodeSignatureR\tsignature\"B\n" +
+	"\fVoteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"O\n" +
+	"\x0fGetBlockRequest\x12\x18\n" +
+	"\x06height\x18\x01 \x01(\x05H\x00R\x06height\x12\x14\n" +
+	"\x04hash\x18\x02 \x01(\tH\x00R\x04hashB\f\n" +
+	"\n" +
+	"identifier\"Q\n" +
+	"\x10GetBlockResponse\x12'\n" +
+	"\x05block\x18\x01 \x01(\v2\x11.blockchain.BlockR\x05block\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\"\x17\n" +
+	"\x15GetLatestBlockRequest\"Y\n" +
+	"\x16GetLatestBlockResponse\x12'\n" +
+	"\x05block\x18\x01 \x01(\v2\x11.blockchain.BlockR\x05block\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\"S\n" +
+	"\x16SendTransactionRequest\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.blockchain.TransactionR\vtransaction\"h\n" +
+	"\x17SendTransactionResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\atx_hash\x18\x03 \x01(\tR\x06txHash\"Q\n" +
+	"\x11SyncBlocksRequest\x12\x1f\n" +
+	"\vfrom_height\x18\x01 \x01(\x05R\n" +
+	"fromHeight\x12\x1b\n" +
+	"\tto_height\x18\x02 \x01(\x05R\btoHeight\"?\n" +
+	"\x12SyncBlocksResponse\x12)\n" +
+	"\x06blocks\x18\x01 \x03(\v2\x11.blockchain.BlockR\x06blocks\"F\n" +
+	"\x1bNotifyCommittedBlockRequest\x12'\n" +
+	"\x05block\x18\x01 \x01(\v2\x11.blockchain.BlockR\x05block\"R\n" +
+	"\x1cNotifyCommittedBlockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x93\x01\n" +
+	"\x05Asset\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\x03 \x01(\x05R\bdecimals\x12\x16\n" +
+	"\x06supply\x18\x04 \x01(\x01R\x06supply\x12\x16\n" +
+	"\x06issuer\x18\x05 \x01(\tR\x06issuer\x12\x16\n" +
+	"\x06height\x18\x06 \x01(\x05R\x06height\"\x13\n" +
+	"\x11ListAssetsRequest\"?\n" +
+	"\x12ListAssetsResponse\x12)\n" +
+	"\x06assets\x18\x01 \x03(\v2\x11.blockchain.AssetR\x06assets\"C\n" +
+	"\x11GetBalanceRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05asset\x18\x02 \x01(\tR\x05asset\"V\n" +
+	"\fAssetBalance\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\x01R\abalance\"J\n" +
+	"\x12GetBalanceResponse\x124\n" +
+	"\bbalances\x18\x01 \x03(\v2\x18.blockchain.AssetBalanceR\bbalances\"(\n" +
+	"\x12ResolveNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"q\n" +
+	"\x13ResolveNameResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06expiry\x18\x04 \x01(\x05R\x06expiry\"&\n" +
+	"\x10GetAnchorRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\"9\n" +
+	"\x0fMerkleProofStep\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x12\n" +
+	"\x04left\x18\x02 \x01(\bR\x04left\"\xdf\x02\n" +
+	"\x11GetAnchorResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x06 \x01(\tR\tblockHash\x12\x1f\n" +
+	"\vmerkle_root\x18\a \x01(\tR\n" +
+	"merkleRoot\x12\x19\n" +
+	"\btx_index\x18\b \x01(\x05R\atxIndex\x129\n" +
+	"\vtransaction\x18\t \x01(\v2\x17.blockchain.TransactionR\vtransaction\x121\n" +
+	"\x05proof\x18\n" +
+	" \x03(\v2\x1b.blockchain.MerkleProofStepR\x05proof\"F\n" +
+	"\x14GetAllowancesRequest\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x18\n" +
+	"\aspender\x18\x02 \x01(\tR\aspender\"i\n" +
+	"\tAllowance\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x18\n" +
+	"\aspender\x18\x02 \x01(\tR\aspender\x12\x14\n" +
+	"\x05asset\x18\x03 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\"N\n" +
+	"\x15GetAllowancesResponse\x125\n" +
+	"\n" +
+	"allowances\x18\x01 \x03(\v2\x15.blockchain.AllowanceR\n" +
+	"allowances\"-\n" +
+	"\x11GetVestingRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"}\n" +
+	"\x0fVestingSchedule\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x03R\x05start\x12\x14\n" +
+	"\x05cliff\x18\x02 \x01(\x03R\x05cliff\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\x03R\x03end\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x01R\x05total\x12\x16\n" +
+	"\x06vested\x18\x05 \x01(\x01R\x06vested\"\xe9\x01\n" +
+	"\x12GetVestingResponse\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x03R\x06height\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\x01R\abalance\x12\x16\n" +
+	"\x06vested\x18\x04 \x01(\x01R\x06vested\x12\x16\n" +
+	"\x06locked\x18\x05 \x01(\x01R\x06locked\x12\x1c\n" +
+	"\tspendable\x18\x06 \x01(\x01R\tspendable\x129\n" +
+	"\tschedules\x18\a \x03(\v2\x1b.blockchain.VestingScheduleR\tschedules\"-\n" +
+	"\x11GetAccountRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"\x87\x02\n" +
+	"\x12GetAccountResponse\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\tR\achainId\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\x04R\x05nonce\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x01R\abalance\x12\x17\n" +
+	"\amin_fee\x18\x05 \x01(\x01R\x06minFee\x12\x16\n" +
+	"\x06height\x18\x06 \x01(\x03R\x06height\x12\x1d\n" +
+	"\n" +
+	"key_scheme\x18\a \x01(\tR\tkeyScheme\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\b \x01(\fR\tpublicKey\x12\x1d\n" +
+	"\n" +
+	"rotated_at\x18\t \x01(\x03R\trotatedAt\"+\n" +
+	"\x15GetTransactionRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\"\xb0\x02\n" +
+	"\x16GetTransactionResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x129\n" +
+	"\vtransaction\x18\x02 \x01(\v2\x17.blockchain.TransactionR\vtransaction\x12\x18\n" +
+	"\apending\x18\x03 \x01(\bR\apending\x12!\n" +
+	"\fblock_height\x18\x04 \x01(\x03R\vblockHeight\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x05 \x01(\tR\tblockHash\x12'\n" +
+	"\x0fblock_timestamp\x18\x06 \x01(\x03R\x0eblockTimestamp\x12\x1a\n" +
+	"\bposition\x18\a \x01(\x05R\bposition\x12$\n" +
+	"\rconfirmations\x18\b \x01(\x03R\rconfirmations\"\xa3\x01\n" +
+	"\x18GetAddressHistoryRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12!\n" +
+	"\foldest_first\x18\x05 \x01(\bR\voldestFirst\"\x81\x02\n" +
+	"\x13AddressHistoryEntry\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\tR\x06txHash\x12!\n" +
+	"\fblock_height\x18\x02 \x01(\x03R\vblockHeight\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\x12'\n" +
+	"\x0fblock_timestamp\x18\x04 \x01(\x03R\x0eblockTimestamp\x12\x12\n" +
+	"\x04sent\x18\x05 \x01(\bR\x04sent\x12\x1a\n" +
+	"\breceived\x18\x06 \x01(\bR\breceived\x129\n" +
+	"\vtransaction\x18\a \x01(\v2\x17.blockchain.TransactionR\vtransaction\"\x91\x01\n" +
+	"\x19GetAddressHistoryResponse\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x129\n" +
+	"\aentries\x18\x02 \x03(\v2\x1f.blockchain.AddressHistoryEntryR\aentries\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"d\n" +
+	"\rNodeSignature\x12\x16\n" +
+	"\x06scheme\x18\x01 \x01(\tR\x06scheme\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\fR\tsignature\"p\n" +
+	"\tSignerKey\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06scheme\x18\x03 \x01(\tR\x06scheme\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x04 \x01(\fR\tpublicKey\"\x11\n" +
+	"\x0fListKeysRequest\"=\n" +
+	"\x10ListKeysResponse\x12)\n" +
+	"\x04keys\x18\x01 \x03(\v2\x15.blockchain.SignerKeyR\x04keys\"e\n" +
+	"\x16SignTransactionRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x129\n" +
+	"\vtransaction\x18\x02 \x01(\v2\x17.blockchain.TransactionR\vtransaction\"^\n" +
+	"\x13SignProposalRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x03R\x06height\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x03 \x01(\tR\tblockHash\"w\n" +
+	"\x0fSignVoteRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x02 \x01(\tR\tblockHash\x12\x19\n" +
+	"\bvoter_id\x18\x03 \x01(\tR\avoterId\x12\x18\n" +
+	"\aapprove\x18\x04 \x01(\bR\aapprove2\xb4\n" +
+	"\n" +
+	"\x11BlockchainService\x12Q\n" +
+	"\fProposeBlock\x12\x1f.blockchain.ProposeBlockRequest\x1a .blockchain.ProposeBlockResponse\x129\n" +
+	"\x04Vote\x12\x17.blockchain.VoteRequest\x1a\x18.blockchain.VoteResponse\x12E\n" +
+	"\bGetBlock\x12\x1b.blockchain.GetBlockRequest\x1a\x1c.blockchain.GetBlockResponse\x12W\n" +
+	"\x0eGetLatestBlock\x12!.blockchain.GetLatestBlockRequest\x1a\".blockchain.GetLatestBlockResponse\x12Z\n" +
+	"\x0fSendTransaction\x12\".blockchain.SendTransactionRequest\x1a#.blockchain.SendTransactionResponse\x12K\n" +
+	"\n" +
+	"SyncBlocks\x12\x1d.blockchain.SyncBlocksRequest\x1a\x1e.blockchain.SyncBlocksResponse\x12i\n" +
+	"\x14NotifyCommittedBlock\x12'.blockchain.NotifyCommittedBlockRequest\x1a(.blockchain.NotifyCommittedBlockResponse\x12K\n" +
+	"\n" +
+	"ListAssets\x12\x1d.blockchain.ListAssetsRequest\x1a\x1e.blockchain.ListAssetsResponse\x12K\n" +
+	"\n" +
+	"GetBalance\x12\x1d.blockchain.GetBalanceRequest\x1a\x1e.blockchain.GetBalanceResponse\x12N\n" +
+	"\vResolveName\x12\x1e.blockchain.ResolveNameRequest\x1a\x1f.blockchain.ResolveNameResponse\x12H\n" +
+	"\tGetAnchor\x12\x1c.blockchain.GetAnchorRequest\x1a\x1d.blockchain.GetAnchorResponse\x12T\n" +
+	"\rGetAllowances\x12 .blockchain.GetAllowancesRequest\x1a!.blockchain.GetAllowancesResponse\x12K\n" +
+	"\n" +
+	"GetVesting\x12\x1d.blockchain.GetVestingRequest\x1a\x1e.blockchain.GetVestingResponse\x12K\n" +
 	"\n" +
-	"SyncBlocks\x12\x1d.blockchain.SyncBlocksRequest\x1a\x1e.blockchain.SyncBlocksResponseB\tZ\a./protob\x06proto3"
+	"GetAccount\x12\x1d.blockchain.GetAccountRequest\x1a\x1e.blockchain.GetAccountResponse\x12W\n" +
+	"\x0eGetTransaction\x12!.blockchain.GetTransactionRequest\x1a\".blockchain.GetTransactionResponse\x12`\n" +
+	"\x11GetAddressHistory\x12$.blockchain.GetAddressHistoryRequest\x1a%.blockchain.GetAddressHistoryResponse2\xb8\x02\n" +
+	"\rSignerService\x12E\n" +
+	"\bListKeys\x12\x1b.blockchain.ListKeysRequest\x1a\x1c.blockchain.ListKeysResponse\x12P\n" +
+	"\x0fSignTransaction\x12\".blockchain.SignTransactionRequest\x1a\x19.blockchain.NodeSignature\x12J\n" +
+	"\fSignProposal\x12\x1f.blockchain.SignProposalRequest\x1a\x19.blockchain.NodeSignature\x12B\n" +
+	"\bSignVote\x12\x1b.blockchain.SignVoteRequest\x1a\x19.blockchain.NodeSignatureB\tZ\a./protob\x06proto3"
 
 var (
 	file_proto_blockchain_proto_rawDescOnce sync.Once
@@ -898,47 +3226,122 @@ func file_proto_blockchain_proto_rawDescGZIP() []byte {
 	return file_proto_blockchain_proto_rawDescData
 }
 
-var file_proto_blockchain_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_blockchain_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_proto_blockchain_proto_goTypes = []any{
-	(*Transaction)(nil),             // 0: blockchain.Transaction
-	(*Block)(nil),                   // 1: blockchain.Block
-	(*ProposeBlockRequest)(nil),     // 2: blockchain.ProposeBlockRequest
-	(*ProposeBlockResponse)(nil),    // 3: blockchain.ProposeBlockResponse
-	(*VoteRequest)(nil),             // 4: blockchain.VoteRequest
-	(*VoteResponse)(nil),            // 5: blockchain.VoteResponse
-	(*GetBlockRequest)(nil),         // 6: blockchain.GetBlockRequest
-	(*GetBlockResponse)(nil),        // 7: blockchain.GetBlockResponse
-	(*GetLatestBlockRequest)(nil),   // 8: blockchain.GetLatestBlockRequest
-	(*GetLatestBlockResponse)(nil),  // 9: blockchain.GetLatestBlockResponse
-	(*SendTransactionRequest)(nil),  // 10: blockchain.SendTransactionRequest
-	(*SendTransactionResponse)(nil), // 11: blockchain.SendTransactionResponse
-	(*SyncBlocksRequest)(nil),       // 12: blockchain.SyncBlocksRequest
-	(*SyncBlocksResponse)(nil),      // 13: blockchain.SyncBlocksResponse
+	(*Transaction)(nil),                  // 0: blockchain.Transaction
+	(*Block)(nil),                        // 1: blockchain.Block
+	(*ProposeBlockRequest)(nil),          // 2: blockchain.ProposeBlockRequest
+	(*ProposeBlockResponse)(nil),         // 3: blockchain.ProposeBlockResponse
+	(*VoteRequest)(nil),                  // 4: blockchain.VoteRequest
+	(*VoteResponse)(nil),                 // 5: blockchain.VoteResponse
+	(*GetBlockRequest)(nil),              // 6: blockchain.GetBlockRequest
+	(*GetBlockResponse)(nil),             // 7: blockchain.GetBlockResponse
+	(*GetLatestBlockRequest)(nil),        // 8: blockchain.GetLatestBlockRequest
+	(*GetLatestBlockResponse)(nil),       // 9: blockchain.GetLatestBlockResponse
+	(*SendTransactionRequest)(nil),       // 10: blockchain.SendTransactionRequest
+	(*SendTransactionResponse)(nil),      // 11: blockchain.SendTransactionResponse
+	(*SyncBlocksRequest)(nil),            // 12: blockchain.SyncBlocksRequest
+	(*SyncBlocksResponse)(nil),           // 13: blockchain.SyncBlocksResponse
+	(*NotifyCommittedBlockRequest)(nil),  // 14: blockchain.NotifyCommittedBlockRequest
+	(*NotifyCommittedBlockResponse)(nil), // 15: blockchain.NotifyCommittedBlockResponse
+	(*Asset)(nil),                        // 16: blockchain.Asset
+	(*ListAssetsRequest)(nil),            // 17: blockchain.ListAssetsRequest
+	(*ListAssetsResponse)(nil),           // 18: blockchain.ListAssetsResponse
+	(*GetBalanceRequest)(nil),            // 19: blockchain.GetBalanceRequest
+	(*AssetBalance)(nil),                 // 20: blockchain.AssetBalance
+	(*GetBalanceResponse)(nil),           // 21: blockchain.GetBalanceResponse
+	(*ResolveNameRequest)(nil),           // 22: blockchain.ResolveNameRequest
+	(*ResolveNameResponse)(nil),          // 23: blockchain.ResolveNameResponse
+	(*GetAnchorRequest)(nil),             // 24: blockchain.GetAnchorRequest
+	(*MerkleProofStep)(nil),              // 25: blockchain.MerkleProofStep
+	(*GetAnchorResponse)(nil),            // 26: blockchain.GetAnchorResponse
+	(*GetAllowancesRequest)(nil),         // 27: blockchain.GetAllowancesRequest
+	(*Allowance)(nil),                    // 28: blockchain.Allowance
+	(*GetAllowancesResponse)(nil),        // 29: blockchain.GetAllowancesResponse
+	(*GetVestingRequest)(nil),            // 30: blockchain.GetVestingRequest
+	(*VestingSchedule)(nil),              // 31: blockchain.VestingSchedule
+	(*GetVestingResponse)(nil),           // 32: blockchain.GetVestingResponse
+	(*GetAccountRequest)(nil),            // 33: blockchain.GetAccountRequest
+	(*GetAccountResponse)(nil),           // 34: blockchain.GetAccountResponse
+	(*GetTransactionRequest)(nil),        // 35: blockchain.GetTransactionRequest
+	(*GetTransactionResponse)(nil),       // 36: blockchain.GetTransactionResponse
+	(*GetAddressHistoryRequest)(nil),     // 37: blockchain.GetAddressHistoryRequest
+	(*AddressHistoryEntry)(nil),          // 38: blockchain.AddressHistoryEntry
+	(*GetAddressHistoryResponse)(nil),    // 39: blockchain.GetAddressHistoryResponse
+	(*NodeSignature)(nil),                // 40: blockchain.NodeSignature
+	(*SignerKey)(nil),                    // 41: blockchain.SignerKey
+	(*ListKeysRequest)(nil),              // 42: blockchain.ListKeysRequest
+	(*ListKeysResponse)(nil),             // 43: blockchain.ListKeysResponse
+	(*SignTransactionRequest)(nil),       // 44: blockchain.SignTransactionRequest
+	(*SignProposalRequest)(nil),          // 45: blockchain.SignProposalRequest
+	(*SignVoteRequest)(nil),              // 46: blockchain.SignVoteRequest
 }
 var file_proto_blockchain_proto_depIdxs = []int32{
 	0,  // 0: blockchain.Block.transactions:type_name -> blockchain.Transaction
 	1,  // 1: blockchain.ProposeBlockRequest.block:type_name -> blockchain.Block
-	1,  // 2: blockchain.GetBlockResponse.block:type_name -> blockchain.Block
-	1,  // 3: blockchain.GetLatestBlockResponse.block:type_name -> blockchain.Block
-	0,  // 4: blockchain.SendTransactionRequest.transaction:type_name -> blockchain.Transaction
-	1,  // 5: blockchain.SyncBlocksResponse.blocks:type_name -> blockchain.Block
-	2,  // 6: blockchain.BlockchainService.ProposeBlock:input_type -> blockchain.ProposeBlockRequest
-	4,  // 7: blockchain.BlockchainService.Vote:input_type -> blockchain.VoteRequest
-	6,  // 8: blockchain.BlockchainService.GetBlock:input_type -> blockchain.GetBlockRequest
-	8,  // 9: blockchain.BlockchainService.GetLatestBlock:input_type -> blockchain.GetLatestBlockRequest
-	10, // 10: blockchain.BlockchainService.SendTransaction:input_type -> blockchain.SendTransactionRequest
-	12, // 11: blockchain.BlockchainService.SyncBlocks:input_type -> blockchain.SyncBlocksRequest
-	3,  // 12: blockchain.BlockchainService.ProposeBlock:output_type -> blockchain.ProposeBlockResponse
-	5,  // 13: blockchain.BlockchainService.Vote:output_type -> blockchain.VoteResponse
-	7,  // 14: blockchain.BlockchainService.GetBlock:output_type -> blockchain.GetBlockResponse
-	9,  // 15: blockchain.BlockchainService.GetLatestBlock:output_type -> blockchain.GetLatestBlockResponse
-	11, // 16: blockchain.BlockchainService.SendTransaction:output_type -> blockchain.SendTransactionResponse
-	13, // 17: blockchain.BlockchainService.SyncBlocks:output_type -> blockchain.SyncBlocksResponse
-	12, // [12:18] is the sub-list for method output_type
-	6,  // [6:12] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	40, // 2: blockchain.ProposeBlockRequest.signature:type_name -> blockchain.NodeSignature
+	40, // 3: blockchain.VoteRequest.signature:type_name -> blockchain.NodeSignature
+	1,  // 4: blockchain.GetBlockResponse.block:type_name -> blockchain.Block
+	1,  // 5: blockchain.GetLatestBlockResponse.block:type_name -> blockchain.Block
+	0,  // 6: blockchain.SendTransactionRequest.transaction:type_name -> blockchain.Transaction
+	1,  // 7: blockchain.SyncBlocksResponse.blocks:type_name -> blockchain.Block
+	1,  // 8: blockchain.NotifyCommittedBlockRequest.block:type_name -> blockchain.Block
+	16, // 9: blockchain.ListAssetsResponse.assets:type_name -> blockchain.Asset
+	20, // 10: blockchain.GetBalanceResponse.balances:type_name -> blockchain.AssetBalance
+	0,  // 11: blockchain.GetAnchorResponse.transaction:type_name -> blockchain.Transaction
+	25, // 12: blockchain.GetAnchorResponse.proof:type_name -> blockchain.MerkleProofStep
+	28, // 13: blockchain.GetAllowancesResponse.allowances:type_name -> blockchain.Allowance
+	31, // 14: blockchain.GetVestingResponse.schedules:type_name -> blockchain.VestingSchedule
+	0,  // 15: blockchain.GetTransactionResponse.transaction:type_name -> blockchain.Transaction
+	0,  // 16: blockchain.AddressHistoryEntry.transaction:type_name -> blockchain.Transaction
+	38, // 17: blockchain.GetAddressHistoryResponse.entries:type_name -> blockchain.AddressHistoryEntry
+	41, // 18: blockchain.ListKeysResponse.keys:type_name -> blockchain.SignerKey
+	0,  // 19: blockchain.SignTransactionRequest.transaction:type_name -> blockchain.Transaction
+	2,  // 20: blockchain.BlockchainService.ProposeBlock:input_type -> blockchain.ProposeBlockRequest
+	4,  // 21: blockchain.BlockchainService.Vote:input_type -> blockchain.VoteRequest
+	6,  // 22: blockchain.BlockchainService.GetBlock:input_type -> blockchain.GetBlockRequest
+	8,  // 23: blockchain.BlockchainService.GetLatestBlock:input_type -> blockchain.GetLatestBlockRequest
+	10, // 24: blockchain.BlockchainService.SendTransaction:input_type -> blockchain.SendTransactionRequest
+	12, // 25: blockchain.BlockchainService.SyncBlocks:input_type -> blockchain.SyncBlocksRequest
+	14, // 26: blockchain.BlockchainService.NotifyCommittedBlock:input_type -> blockchain.NotifyCommittedBlockRequest
+	17, // 27: blockchain.BlockchainService.ListAssets:input_type -> blockchain.ListAssetsRequest
+	19, // 28: blockchain.BlockchainService.GetBalance:input_type -> blockchain.GetBalanceRequest
+	22, // 29: blockchain.BlockchainService.ResolveName:input_type -> blockchain.ResolveNameRequest
+	24, // 30: blockchain.BlockchainService.GetAnchor:input_type -> blockchain.GetAnchorRequest
+	27, // 31: blockchain.BlockchainService.GetAllowances:input_type -> blockchain.GetAllowancesRequest
+	30, // 32: blockchain.BlockchainService.GetVesting:input_type -> blockchain.GetVestingRequest
+	33, // 33: blockchain.BlockchainService.GetAccount:input_type -> blockchain.GetAccountRequest
+	35, // 34: blockchain.BlockchainService.GetTransaction:input_type -> blockchain.GetTransactionRequest
+	37, // 35: blockchain.BlockchainService.GetAddressHistory:input_type -> blockchain.GetAddressHistoryRequest
+	42, // 36: blockchain.SignerService.ListKeys:input_type -> blockchain.ListKeysRequest
+	44, // 37: blockchain.SignerService.SignTransaction:input_type -> blockchain.SignTransactionRequest
+	45, // 38: blockchain.SignerService.SignProposal:input_type -> blockchain.SignProposalRequest
+	46, // 39: blockchain.SignerService.SignVote:input_type -> blockchain.SignVoteRequest
+	3,  // 40: blockchain.BlockchainService.ProposeBlock:output_type -> blockchain.ProposeBlockResponse
+	5,  // 41: blockchain.BlockchainService.Vote:output_type -> blockchain.VoteResponse
+	7,  // 42: blockchain.BlockchainService.GetBlock:output_type -> blockchain.GetBlockResponse
+	9,  // 43: blockchain.BlockchainService.GetLatestBlock:output_type -> blockchain.GetLatestBlockResponse
+	11, // 44: blockchain.BlockchainService.SendTransaction:output_type -> blockchain.SendTransactionResponse
+	13, // 45: blockchain.BlockchainService.SyncBlocks:output_type -> blockchain.SyncBlocksResponse
+	15, // 46: blockchain.BlockchainService.NotifyCommittedBlock:output_type -> blockchain.NotifyCommittedBlockResponse
+	18, // 47: blockchain.BlockchainService.ListAssets:output_type -> blockchain.ListAssetsResponse
+	21, // 48: blockchain.BlockchainService.GetBalance:output_type -> blockchain.GetBalanceResponse
+	23, // 49: blockchain.BlockchainService.ResolveName:output_type -> blockchain.ResolveNameResponse
+	26, // 50: blockchain.BlockchainService.GetAnchor:output_type -> blockchain.GetAnchorResponse
+	29, // 51: blockchain.BlockchainService.GetAllowances:output_type -> blockchain.GetAllowancesResponse
+	32, // 52: blockchain.BlockchainService.GetVesting:output_type -> blockchain.GetVestingResponse
+	34, // 53: blockchain.BlockchainService.GetAccount:output_type -> blockchain.GetAccountResponse
+	36, // 54: blockchain.BlockchainService.GetTransaction:output_type -> blockchain.GetTransactionResponse
+	39, // 55: blockchain.BlockchainService.GetAddressHistory:output_type -> blockchain.GetAddressHistoryResponse
+	43, // 56: blockchain.SignerService.ListKeys:output_type -> blockchain.ListKeysResponse
+	40, // 57: blockchain.SignerService.SignTransaction:output_type -> blockchain.NodeSignature
+	40, // 58: blockchain.SignerService.SignProposal:output_type -> blockchain.NodeSignature
+	40, // 59: blockchain.SignerService.SignVote:output_type -> blockchain.NodeSignature
+	40, // [40:60] is the sub-list for method output_type
+	20, // [20:40] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_proto_blockchain_proto_init() }
@@ -956,9 +3359,9 @@ func file_proto_blockchain_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_blockchain_proto_rawDesc), len(file_proto_blockchain_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   47,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_proto_blockchain_proto_goTypes,
 		DependencyIndexes: file_proto_blockchain_proto_depIdxs,
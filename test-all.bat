@@ -18,13 +18,13 @@ echo Test 2: Individual Component Tests
 echo ====================================================
 
 echo Testing Alice wallet creation...
-blockchain.exe create-alice
+blockchain.exe account-create alice
 
 echo Testing Bob wallet creation...
-blockchain.exe create-bob
+blockchain.exe account-create bob
 
 echo Testing Alice to Bob transaction...
-blockchain.exe alice-to-bob 25.5
+blockchain.exe send bob 25.5 --from alice
 
 echo.
 echo ====================================================
@@ -80,7 +80,7 @@ echo AVAILABLE OPERATIONS:
 echo 1. Check logs       : docker-compose logs -f [node1|node2|node3]
 echo 2. Stop nodes       : docker-compose down
 echo 3. Node status      : docker-compose ps
-echo 4. Test consensus   : bin\blockchain-cli.exe -server localhost:50051 -cmd send -sender Alice -receiver Bob -amount 100
+echo 4. Test consensus   : bin\blockchain-cli.exe -server localhost:50051 -cmd send -key alice_key.json -receiver Bob -amount 100
 echo 5. Check blockchain : bin\blockchain-cli.exe -server localhost:50051 -cmd latest
 echo.
 echo FOR TECHNICAL INTERVIEW DEMO: